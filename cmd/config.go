@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+	"web3-account-abstraction-api/internal/chain"
+	"web3-account-abstraction-api/internal/fee"
+	"web3-account-abstraction-api/internal/gas"
+	"web3-account-abstraction-api/internal/keys"
+	"web3-account-abstraction-api/internal/pricing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	RPCURL                    string        `mapstructure:"RPC_URL"`
+	APIKey                    string        `mapstructure:"API_KEY"`
+	UserPrivateKey            string        `mapstructure:"PRIVATE_KEY"`
+	PaymasterSignerPrivateKey string        `mapstructure:"PAYMASTER_SIGNER_PRIVATE_KEY"`
+	EntryPointAddress         string        `mapstructure:"ENTRYPOINT_ADDRESS"`
+	EntryPointVersion         string        `mapstructure:"ENTRYPOINT_VERSION"`
+	AccountFactoryAddress     string        `mapstructure:"ACCOUNT_FACTORY_ADDRESS"`
+	PaymasterAddress          string        `mapstructure:"PAYMASTER_ADDRESS"`
+	DBDriver                  string        `mapstructure:"DB_DRIVER"`
+	DBPath                    string        `mapstructure:"SQLITE_DB_PATH"`
+	PostgresDSN               string        `mapstructure:"POSTGRES_DSN"`
+	DBMaxOpenConns            int           `mapstructure:"DB_MAX_OPEN_CONNS"`
+	DBMaxIdleConns            int           `mapstructure:"DB_MAX_IDLE_CONNS"`
+	DBConnMaxLifetime         time.Duration `mapstructure:"DB_CONN_MAX_LIFETIME"`
+	BundlerURL                string        `mapstructure:"BUNDLER_URL"`
+	BundlerProvider           string        `mapstructure:"BUNDLER_PROVIDER"`
+	ReconcileInterval         time.Duration `mapstructure:"RECONCILE_INTERVAL"`
+	UserOperationTimeout      time.Duration `mapstructure:"USEROP_TIMEOUT"`
+	// hex encoded AES-256 key; when set every new wallet gets its own owner
+	// key, encrypted with it
+	MasterKey string `mapstructure:"MASTER_KEY"`
+	// when set, chains are read from this file instead of the single chain
+	// variables above
+	ChainsFile string `mapstructure:"CHAINS_FILE"`
+	// wei, see chain.Config.PaymasterDepositThreshold
+	PaymasterDepositThreshold string        `mapstructure:"PAYMASTER_DEPOSIT_THRESHOLD"`
+	DepositMonitorInterval    time.Duration `mapstructure:"DEPOSIT_MONITOR_INTERVAL"`
+	// low deposit alerts are POSTed here as JSON when set
+	AlertWebhookURL string `mapstructure:"ALERT_WEBHOOK_URL"`
+	// see chain.Config.PaymasterToken and pricing.Config
+	PaymasterToken     string        `mapstructure:"PAYMASTER_TOKEN"`
+	PriceSource        string        `mapstructure:"PRICE_SOURCE"`
+	PriceTokenPerEther string        `mapstructure:"PRICE_TOKEN_PER_ETHER"`
+	PriceOracle        string        `mapstructure:"PRICE_ORACLE"`
+	PriceFeed          string        `mapstructure:"PRICE_FEED"`
+	PriceMaxAge        time.Duration `mapstructure:"PRICE_MAX_AGE"`
+	// see fee.Config
+	FeeStrategy             string `mapstructure:"FEE_STRATEGY"`
+	FeeBlockCount           uint64 `mapstructure:"FEE_BLOCK_COUNT"`
+	FeeMaxFeePerGas         string `mapstructure:"FEE_MAX_FEE_PER_GAS"`
+	FeeMaxPriorityFeePerGas string `mapstructure:"FEE_MAX_PRIORITY_FEE_PER_GAS"`
+	FeeMaxFeeCap            string `mapstructure:"FEE_MAX_FEE_CAP"`
+	FeeMaxPriorityFeeCap    string `mapstructure:"FEE_MAX_PRIORITY_FEE_CAP"`
+	// markup of every estimated gas field, see gas.Config; per field and
+	// per target markups need a chains file
+	GasMarkupPercent uint64 `mapstructure:"GAS_MARKUP_PERCENT"`
+	// see gas.Config.Simulation
+	GasSimulation string `mapstructure:"GAS_SIMULATION"`
+}
+
+func LoadConfig(path string, env string) (Config, error) {
+	viper.AddConfigPath(path)
+	viper.SetConfigName(env)
+	viper.SetConfigType("env")
+
+	viper.AutomaticEnv()
+
+	err := viper.ReadInConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	var config Config
+	err = viper.Unmarshal(&config)
+	return config, err
+}
+
+// chainConfigs returns the configured chains: the chains file if any,
+// otherwise the single chain described by the env variables.
+func (c Config) chainConfigs() ([]chain.Config, error) {
+	if c.ChainsFile != "" {
+		return chain.LoadConfigs(c.ChainsFile)
+	}
+
+	return []chain.Config{{
+		RPCURL:                    c.RPCURL,
+		BundlerURL:                c.BundlerURL,
+		BundlerProvider:           c.BundlerProvider,
+		EntryPointAddress:         c.EntryPointAddress,
+		EntryPointVersion:         c.EntryPointVersion,
+		AccountFactoryAddress:     c.AccountFactoryAddress,
+		PaymasterAddress:          c.PaymasterAddress,
+		PrivateKey:                c.UserPrivateKey,
+		PaymasterSignerPrivateKey: c.PaymasterSignerPrivateKey,
+		PaymasterDepositThreshold: c.PaymasterDepositThreshold,
+		PaymasterToken:            c.PaymasterToken,
+		Pricing: pricing.Config{
+			Type:          c.PriceSource,
+			TokenPerEther: c.PriceTokenPerEther,
+			Oracle:        c.PriceOracle,
+			Feed:          c.PriceFeed,
+			MaxAge:        c.PriceMaxAge,
+		},
+		Fees: fee.Config{
+			Strategy:             c.FeeStrategy,
+			BlockCount:           c.FeeBlockCount,
+			MaxFeePerGas:         c.FeeMaxFeePerGas,
+			MaxPriorityFeePerGas: c.FeeMaxPriorityFeePerGas,
+			MaxFeeCap:            c.FeeMaxFeeCap,
+			MaxPriorityFeeCap:    c.FeeMaxPriorityFeeCap,
+		},
+		Gas: gas.Config{
+			Default:    gas.Markup{Percent: c.GasMarkupPercent},
+			Simulation: c.GasSimulation,
+		},
+	}}, nil
+}
+
+func (c Config) masterKey() (*keys.MasterKey, error) {
+	if c.MasterKey == "" {
+		return nil, nil
+	}
+	return keys.NewMasterKey(c.MasterKey)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration, optionally dialing every chain",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(".", envName)
+		if err != nil {
+			return err
+		}
+		switch config.DBDriver {
+		case "", "sqlite":
+			if config.DBPath == "" {
+				return fmt.Errorf("SQLITE_DB_PATH is required")
+			}
+		case "postgres":
+			if config.PostgresDSN == "" {
+				return fmt.Errorf("POSTGRES_DSN is required")
+			}
+		default:
+			return fmt.Errorf("unknown DB_DRIVER %q", config.DBDriver)
+		}
+
+		_, err = config.masterKey()
+		if err != nil {
+			return fmt.Errorf("MASTER_KEY: %w", err)
+		}
+
+		chainConfigs, err := config.chainConfigs()
+		if err != nil {
+			return err
+		}
+
+		dial, _ := cmd.Flags().GetBool("dial")
+		for i, chainConfig := range chainConfigs {
+			err = chainConfig.Validate()
+			if err != nil {
+				return fmt.Errorf("chain %d (%s): %w", i, chainConfig.Name, err)
+			}
+			if !dial {
+				continue
+			}
+
+			ch, err := chain.Connect(chainConfig, nil, nil)
+			if err != nil {
+				return fmt.Errorf("chain %d (%s): %w", i, chainConfig.Name, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "chain %d reachable\n", ch.ID)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%d chain(s) configured, configuration is valid\n", len(chainConfigs))
+		return nil
+	},
+}
+
+func init() {
+	configValidateCmd.Flags().Bool("dial", false, "connect to every RPC and bundler")
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"web3-account-abstraction-api/internal/store/migration"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply pending database migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(".", envName)
+		if err != nil {
+			return err
+		}
+		database, err := openDatabase(config)
+		if err != nil {
+			return err
+		}
+		defer database.db.Close()
+
+		count, err := migration.Up(database.db, database.migrations, database.migrationLock)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "applied %d migration(s)\n", count)
+		return nil
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Revert the latest applied migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		steps, _ := cmd.Flags().GetInt("steps")
+
+		config, err := LoadConfig(".", envName)
+		if err != nil {
+			return err
+		}
+		database, err := openDatabase(config)
+		if err != nil {
+			return err
+		}
+		defer database.db.Close()
+
+		count, err := migration.Down(database.db, database.migrations, steps, database.migrationLock)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "reverted %d migration(s)\n", count)
+		return nil
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "List migrations and whether they are applied",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := LoadConfig(".", envName)
+		if err != nil {
+			return err
+		}
+		database, err := openDatabase(config)
+		if err != nil {
+			return err
+		}
+		defer database.db.Close()
+
+		statuses, err := migration.Statuses(database.db, database.migrations)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%04d %-30s %s\n", status.Version, status.Name, state)
+		}
+		return nil
+	},
+}
+
+func init() {
+	migrateDownCmd.Flags().Int("steps", 1, "number of migrations to revert")
+
+	migrateCmd.AddCommand(migrateDownCmd, migrateStatusCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
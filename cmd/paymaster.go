@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	contract "web3-account-abstraction-api/internal/contracts"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+var paymasterCmd = &cobra.Command{
+	Use:   "paymaster",
+	Short: "Manage the paymaster's EntryPoint deposit and stake",
+}
+
+var paymasterInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show the paymaster's deposit and stake",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ch, _, err := setup()
+		if err != nil {
+			return err
+		}
+
+		info, err := ch.Contracts.GetPaymasterDepositInfo()
+		if err != nil {
+			return err
+		}
+		return printJSON(cmd, info)
+	},
+}
+
+var paymasterDepositCmd = &cobra.Command{
+	Use:   "deposit <amount>",
+	Short: "Top up the paymaster's deposit by amount wei",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		amount, err := parseWei(args[0])
+		if err != nil {
+			return err
+		}
+		ch, _, err := setup()
+		if err != nil {
+			return err
+		}
+
+		tx, err := ch.Contracts.DepositPaymaster(amount)
+		if err != nil {
+			return err
+		}
+		return waitTransaction(cmd, &ch.Contracts, tx)
+	},
+}
+
+var paymasterWithdrawCmd = &cobra.Command{
+	Use:   "withdraw <amount>",
+	Short: "Withdraw amount wei of the paymaster's deposit",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		amount, err := parseWei(args[0])
+		if err != nil {
+			return err
+		}
+		toFlag, _ := cmd.Flags().GetString("to")
+		if !common.IsHexAddress(toFlag) {
+			return fmt.Errorf("invalid to address %q", toFlag)
+		}
+		ch, _, err := setup()
+		if err != nil {
+			return err
+		}
+
+		tx, err := ch.Contracts.WithdrawPaymasterDeposit(common.HexToAddress(toFlag), amount)
+		if err != nil {
+			return err
+		}
+		return waitTransaction(cmd, &ch.Contracts, tx)
+	},
+}
+
+var paymasterStakeCmd = &cobra.Command{
+	Use:   "stake <amount>",
+	Short: "Add amount wei to the paymaster's stake",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		amount, err := parseWei(args[0])
+		if err != nil {
+			return err
+		}
+		unstakeDelay, _ := cmd.Flags().GetUint32("unstake-delay")
+		if unstakeDelay == 0 {
+			return fmt.Errorf("--unstake-delay is required")
+		}
+		ch, _, err := setup()
+		if err != nil {
+			return err
+		}
+
+		tx, err := ch.Contracts.AddPaymasterStake(unstakeDelay, amount)
+		if err != nil {
+			return err
+		}
+		return waitTransaction(cmd, &ch.Contracts, tx)
+	},
+}
+
+var paymasterUnlockStakeCmd = &cobra.Command{
+	Use:   "unlock-stake",
+	Short: "Start the unstake delay of the paymaster's stake",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ch, _, err := setup()
+		if err != nil {
+			return err
+		}
+
+		tx, err := ch.Contracts.UnlockPaymasterStake()
+		if err != nil {
+			return err
+		}
+		return waitTransaction(cmd, &ch.Contracts, tx)
+	},
+}
+
+var paymasterWithdrawStakeCmd = &cobra.Command{
+	Use:   "withdraw-stake",
+	Short: "Withdraw the paymaster's unlocked stake",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		toFlag, _ := cmd.Flags().GetString("to")
+		if !common.IsHexAddress(toFlag) {
+			return fmt.Errorf("invalid to address %q", toFlag)
+		}
+		ch, _, err := setup()
+		if err != nil {
+			return err
+		}
+
+		tx, err := ch.Contracts.WithdrawPaymasterStake(common.HexToAddress(toFlag))
+		if err != nil {
+			return err
+		}
+		return waitTransaction(cmd, &ch.Contracts, tx)
+	},
+}
+
+func init() {
+	paymasterWithdrawCmd.Flags().String("to", "", "address receiving the withdrawal")
+	paymasterWithdrawCmd.MarkFlagRequired("to")
+	paymasterStakeCmd.Flags().Uint32("unstake-delay", 0, "seconds the stake stays locked after unlocking")
+	paymasterWithdrawStakeCmd.Flags().String("to", "", "address receiving the stake")
+	paymasterWithdrawStakeCmd.MarkFlagRequired("to")
+
+	paymasterCmd.AddCommand(paymasterInfoCmd, paymasterDepositCmd, paymasterWithdrawCmd, paymasterStakeCmd, paymasterUnlockStakeCmd, paymasterWithdrawStakeCmd)
+	rootCmd.AddCommand(paymasterCmd)
+}
+
+// parseWei parses a positive wei amount, decimal or 0x prefixed hex.
+func parseWei(s string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(s, 0)
+	if !ok || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	return amount, nil
+}
+
+// waitTransaction prints the hash of tx and waits for it to be mined.
+func waitTransaction(cmd *cobra.Command, contracts *contract.Contracts, tx *types.Transaction) error {
+	fmt.Fprintf(cmd.OutOrStdout(), "sent %s, waiting for it to be mined\n", tx.Hash())
+	receipt, err := contracts.WaitMined(cmd.Context(), tx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "mined in block %s\n", receipt.BlockNumber)
+	return nil
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"web3-account-abstraction-api/internal/chain"
+	"web3-account-abstraction-api/internal/store"
+	"web3-account-abstraction-api/internal/store/migration"
+	postgres_store "web3-account-abstraction-api/internal/store/postgres"
+	sqlite_store "web3-account-abstraction-api/internal/store/sqlite"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	envName string
+	chainId int64
+)
+
+var rootCmd = &cobra.Command{
+	Use:           "erc4337-api",
+	Short:         "ERC-4337 account abstraction API",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&envName, "env", "testnet", "name of the env file to load from the working directory")
+	rootCmd.PersistentFlags().Int64Var(&chainId, "chain", 0, "chain id to act on, defaults to the first configured chain")
+}
+
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+type database struct {
+	db         *sql.DB
+	store      store.Store
+	migrations []migration.Migration
+	// nil when only one process uses the database
+	migrationLock *migration.Lock
+}
+
+// openDatabase opens the store selected by DB_DRIVER along with its
+// migrations.
+func openDatabase(config Config) (database, error) {
+	switch config.DBDriver {
+	case "", "sqlite":
+		db, err := sql.Open("sqlite3", config.DBPath)
+		if err != nil {
+			return database{}, err
+		}
+		migrations, err := sqlite_store.Migrations()
+		if err != nil {
+			return database{}, err
+		}
+		return database{db: db, store: sqlite_store.NewStore(db), migrations: migrations}, nil
+	case "postgres":
+		db, err := postgres_store.Open(config.PostgresDSN, postgres_store.PoolConfig{
+			MaxOpenConns:    config.DBMaxOpenConns,
+			MaxIdleConns:    config.DBMaxIdleConns,
+			ConnMaxLifetime: config.DBConnMaxLifetime,
+		})
+		if err != nil {
+			return database{}, err
+		}
+		migrations, err := postgres_store.Migrations()
+		if err != nil {
+			return database{}, err
+		}
+		return database{
+			db:            db,
+			store:         postgres_store.NewStore(db),
+			migrations:    migrations,
+			migrationLock: postgres_store.MigrationLock,
+		}, nil
+	default:
+		return database{}, fmt.Errorf("unknown database driver %q", config.DBDriver)
+	}
+}
+
+// connectChain connects the chain selected by --chain.
+func connectChain(config Config, s store.Store) (*chain.Chain, error) {
+	chainConfigs, err := config.chainConfigs()
+	if err != nil {
+		return nil, err
+	}
+	masterKey, err := config.masterKey()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chainConfig := range chainConfigs {
+		if chainId != 0 && chainConfig.ChainID != 0 && chainConfig.ChainID != chainId {
+			continue
+		}
+		// a zero chain id is only known once connected
+		ch, err := chain.Connect(chainConfig, s, masterKey)
+		if err != nil {
+			return nil, err
+		}
+		if chainId == 0 || ch.ID == chainId {
+			return ch, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d", chain.ErrUnknownChain, chainId)
+}
+
+// setup loads the config and connects the store and the selected chain.
+func setup() (*chain.Chain, store.Store, error) {
+	config, err := LoadConfig(".", envName)
+	if err != nil {
+		return nil, nil, err
+	}
+	database, err := openDatabase(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	ch, err := connectChain(config, database.store)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ch, database.store, nil
+}
+
+func printJSON(cmd *cobra.Command, v any) error {
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"web3-account-abstraction-api/internal/api"
+	"web3-account-abstraction-api/internal/chain"
+	"web3-account-abstraction-api/internal/monitor"
+	"web3-account-abstraction-api/internal/reconciler"
+	"web3-account-abstraction-api/internal/store/migration"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the HTTP API and the user operation reconcilers",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listen, _ := cmd.Flags().GetString("listen")
+		migrate, _ := cmd.Flags().GetBool("migrate")
+
+		config, err := LoadConfig(".", envName)
+		if err != nil {
+			return err
+		}
+		database, err := openDatabase(config)
+		if err != nil {
+			return err
+		}
+		store := database.store
+		if migrate {
+			count, err := migration.Up(database.db, database.migrations, database.migrationLock)
+			if err != nil {
+				return err
+			}
+			log.Printf("applied %d migration(s)", count)
+		}
+
+		chainConfigs, err := config.chainConfigs()
+		if err != nil {
+			return err
+		}
+		masterKey, err := config.masterKey()
+		if err != nil {
+			return err
+		}
+
+		chains := chain.NewRegistry()
+		for _, chainConfig := range chainConfigs {
+			c, err := chain.Connect(chainConfig, store, masterKey)
+			if err != nil {
+				return err
+			}
+			err = chains.Register(c)
+			if err != nil {
+				return err
+			}
+
+			r := reconciler.NewReconciler(c.ID, store, c.Bundler, c.Contracts.PaymasterAddress, c.Contracts.TokenAddress(), config.ReconcileInterval, config.UserOperationTimeout)
+			go r.Run(context.Background())
+
+			threshold, err := chainConfig.DepositThreshold()
+			if err != nil {
+				return err
+			}
+			if threshold != nil {
+				m := monitor.NewDepositMonitor(c.ID, &c.Contracts, threshold, config.DepositMonitorInterval, config.AlertWebhookURL)
+				go m.Run(context.Background())
+			}
+		}
+
+		e := echo.New()
+
+		api.SetupResponses(e)
+		// after the request ID, so a panic is answered as an internal error
+		// in the error envelope with the request's ID
+		e.Use(middleware.Recover())
+		api.SetupAPI(e, store, chains)
+		api.SetupAdminAPI(e, store, chains, config.APIKey)
+
+		return e.Start(listen)
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("listen", ":8080", "address the API listens on")
+	serveCmd.Flags().Bool("migrate", true, "apply pending migrations before serving")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"web3-account-abstraction-api/internal/signer"
+
+	"github.com/spf13/cobra"
+)
+
+var signerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Remote signer tools",
+}
+
+var signerServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the remote signer protocol for a local key, as a stand-in for a signing service",
+	Long: `Serves the remote signer protocol for one key. The key is read from
+--keystore (password in SIGNER_KEYSTORE_PASSWORD) or from SIGNER_PRIVATE_KEY.
+When SIGNER_TOKEN is set, requests must carry it as a bearer token.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listen, _ := cmd.Flags().GetString("listen")
+		keystorePath, _ := cmd.Flags().GetString("keystore")
+
+		var s signer.Signer
+		var err error
+		switch {
+		case keystorePath != "":
+			s, err = signer.NewKeystoreSigner(keystorePath, os.Getenv("SIGNER_KEYSTORE_PASSWORD"))
+		case os.Getenv("SIGNER_PRIVATE_KEY") != "":
+			s, err = signer.ParseKey(os.Getenv("SIGNER_PRIVATE_KEY"))
+		default:
+			err = errors.New("set --keystore or SIGNER_PRIVATE_KEY")
+		}
+		if err != nil {
+			return err
+		}
+
+		log.Printf("remote signer for %s listening on %s", s.Address(), listen)
+		return http.ListenAndServe(listen, signer.Handler(s, os.Getenv("SIGNER_TOKEN")))
+	},
+}
+
+func init() {
+	signerServeCmd.Flags().String("listen", "127.0.0.1:8551", "address the signer listens on")
+	signerServeCmd.Flags().String("keystore", "", "go-ethereum keystore JSON file")
+
+	signerCmd.AddCommand(signerServeCmd)
+	rootCmd.AddCommand(signerCmd)
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/usecase"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+var userOpCmd = &cobra.Command{
+	Use:   "userop",
+	Short: "Send and inspect user operations",
+}
+
+var userOpStatusCmd = &cobra.Command{
+	Use:   "status <hash>",
+	Short: "Print the stored status of a user operation",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ch, _, err := setup()
+		if err != nil {
+			return err
+		}
+
+		record, err := ch.Usecase.GetUserOperationStatus(args[0])
+		if err != nil {
+			return err
+		}
+		return printJSON(cmd, record)
+	},
+}
+
+var userOpSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Send a call from a wallet through Account.execute",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		walletFlag, _ := cmd.Flags().GetString("wallet")
+		toFlag, _ := cmd.Flags().GetString("to")
+		valueFlag, _ := cmd.Flags().GetString("value")
+		dataFlag, _ := cmd.Flags().GetString("data")
+		selfPaid, _ := cmd.Flags().GetBool("self-paid")
+		tierFlag, _ := cmd.Flags().GetString("tier")
+
+		if !common.IsHexAddress(walletFlag) {
+			return fmt.Errorf("invalid wallet address %q", walletFlag)
+		}
+		if !common.IsHexAddress(toFlag) {
+			return fmt.Errorf("invalid target address %q", toFlag)
+		}
+		tier, err := model.ParseFeeTier(tierFlag)
+		if err != nil {
+			return err
+		}
+		value, ok := big.NewInt(0).SetString(valueFlag, 0)
+		if !ok {
+			return fmt.Errorf("invalid value %q", valueFlag)
+		}
+		var data []byte
+		if dataFlag != "" {
+			data, err = hexutil.Decode(dataFlag)
+			if err != nil {
+				return err
+			}
+		}
+
+		ch, store, err := setup()
+		if err != nil {
+			return err
+		}
+		_, err = store.GetWallet(ch.ID, walletFlag)
+		if err != nil {
+			return fmt.Errorf("wallet %s: %w", walletFlag, err)
+		}
+
+		hash, err := ch.Usecase.Execute(common.HexToAddress(walletFlag), usecase.Call{
+			Target: common.HexToAddress(toFlag),
+			Value:  value,
+			Data:   data,
+		}, usecase.ExecuteOptions{SelfPaid: selfPaid, FeeTier: tier})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), hash)
+		return nil
+	},
+}
+
+func init() {
+	userOpSendCmd.Flags().String("wallet", "", "sending wallet address")
+	userOpSendCmd.Flags().String("to", "", "call target address")
+	userOpSendCmd.Flags().String("value", "0", "wei sent with the call")
+	userOpSendCmd.Flags().String("data", "", "0x-prefixed calldata")
+	userOpSendCmd.Flags().String("tier", "normal", "fee tier: slow, normal or fast")
+	userOpSendCmd.Flags().Bool("self-paid", false, "pay gas from the wallet's deposit instead of the paymaster")
+	userOpSendCmd.MarkFlagRequired("wallet")
+	userOpSendCmd.MarkFlagRequired("to")
+
+	userOpCmd.AddCommand(userOpStatusCmd, userOpSendCmd)
+	rootCmd.AddCommand(userOpCmd)
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"web3-account-abstraction-api/internal/usecase"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var walletCmd = &cobra.Command{
+	Use:   "wallet",
+	Short: "Manage wallets",
+}
+
+var walletCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Record the next wallet and deploy it",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ch, _, err := setup()
+		if err != nil {
+			return err
+		}
+
+		counterfactual, _ := cmd.Flags().GetBool("counterfactual")
+		ownerFlag, _ := cmd.Flags().GetString("owner")
+		opts := usecase.CreateWalletOptions{Counterfactual: counterfactual}
+		if ownerFlag != "" {
+			if !common.IsHexAddress(ownerFlag) {
+				return fmt.Errorf("invalid owner address %q", ownerFlag)
+			}
+			owner := common.HexToAddress(ownerFlag)
+			opts.Owner = &owner
+		}
+
+		wallet, err := ch.Usecase.CreateWallet(opts)
+		if err != nil {
+			return err
+		}
+		return printJSON(cmd, wallet)
+	},
+}
+
+var walletDeployCmd = &cobra.Command{
+	Use:   "deploy <address>",
+	Short: "Deploy a reserved or counterfactual wallet",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ch, store, err := setup()
+		if err != nil {
+			return err
+		}
+
+		wallet, err := store.GetWallet(ch.ID, args[0])
+		if err != nil {
+			return err
+		}
+		wallet, err = ch.Usecase.DeployWallet(wallet)
+		if err != nil {
+			return err
+		}
+		return printJSON(cmd, wallet)
+	},
+}
+
+var walletListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the recorded wallets",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ch, store, err := setup()
+		if err != nil {
+			return err
+		}
+
+		wallets, err := store.GetAllWallet(ch.ID)
+		if err != nil {
+			return err
+		}
+		return printJSON(cmd, wallets)
+	},
+}
+
+var walletAddressCmd = &cobra.Command{
+	Use:   "address",
+	Short: "Print the counterfactual address for a salt",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ch, _, err := setup()
+		if err != nil {
+			return err
+		}
+
+		var salt [32]byte
+		saltFlag, _ := cmd.Flags().GetString("salt")
+		saltInt, ok := big.NewInt(0).SetString(saltFlag, 0)
+		if !ok || saltInt.Sign() < 0 || saltInt.BitLen() > 256 {
+			return fmt.Errorf("invalid salt %q", saltFlag)
+		}
+		saltInt.FillBytes(salt[:])
+
+		var owner common.Address
+		ownerFlag, _ := cmd.Flags().GetString("owner")
+		if ownerFlag != "" {
+			if !common.IsHexAddress(ownerFlag) {
+				return fmt.Errorf("invalid owner address %q", ownerFlag)
+			}
+			owner = common.HexToAddress(ownerFlag)
+		}
+
+		addr, err := ch.Usecase.WalletAddress(owner, salt)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), addr.Hex())
+		return nil
+	},
+}
+
+func init() {
+	walletCreateCmd.Flags().Bool("counterfactual", false, "only record the predicted address, deploy with the first operation")
+	walletCreateCmd.Flags().String("owner", "", "client held owner address; the wallet is counterfactual and signs client-side")
+	walletAddressCmd.Flags().String("salt", "", "salt as a decimal or 0x-prefixed number")
+	walletAddressCmd.MarkFlagRequired("salt")
+	walletAddressCmd.Flags().String("owner", "", "owner address, defaults to the configured PRIVATE_KEY")
+
+	walletCmd.AddCommand(walletCreateCmd, walletDeployCmd, walletListCmd, walletAddressCmd)
+	rootCmd.AddCommand(walletCmd)
+}
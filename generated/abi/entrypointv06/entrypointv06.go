@@ -0,0 +1,2262 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package entrypointv06
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// EntryPointMemoryUserOp is an auto generated low-level Go binding around an user-defined struct.
+type EntryPointMemoryUserOp struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	Paymaster            common.Address
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// EntryPointUserOpInfo is an auto generated low-level Go binding around an user-defined struct.
+type EntryPointUserOpInfo struct {
+	MUserOp       EntryPointMemoryUserOp
+	UserOpHash    [32]byte
+	Prefund       *big.Int
+	ContextOffset *big.Int
+	PreOpGas      *big.Int
+}
+
+// IEntryPointUserOpsPerAggregator is an auto generated low-level Go binding around an user-defined struct.
+type IEntryPointUserOpsPerAggregator struct {
+	UserOps    []UserOperation
+	Aggregator common.Address
+	Signature  []byte
+}
+
+// IStakeManagerDepositInfo is an auto generated low-level Go binding around an user-defined struct.
+type IStakeManagerDepositInfo struct {
+	Deposit         *big.Int
+	Staked          bool
+	Stake           *big.Int
+	UnstakeDelaySec uint32
+	WithdrawTime    *big.Int
+}
+
+// UserOperation is an auto generated low-level Go binding around an user-defined struct.
+type UserOperation struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+// EntryPointV06MetaData contains all meta data concerning the EntryPointV06 contract.
+var EntryPointV06MetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"preOpGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"paid\",\"type\":\"uint256\"},{\"internalType\":\"uint48\",\"name\":\"validAfter\",\"type\":\"uint48\"},{\"internalType\":\"uint48\",\"name\":\"validUntil\",\"type\":\"uint48\"},{\"internalType\":\"bool\",\"name\":\"targetSuccess\",\"type\":\"bool\"},{\"internalType\":\"bytes\",\"name\":\"targetResult\",\"type\":\"bytes\"}],\"name\":\"ExecutionResult\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"opIndex\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"reason\",\"type\":\"string\"}],\"name\":\"FailedOp\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"SenderAddressResult\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"aggregator\",\"type\":\"address\"}],\"name\":\"SignatureValidationFailed\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"structIEntryPoint.ReturnInfo\",\"name\":\"returnInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"uint256\",\"name\":\"preOpGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"prefund\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"sigFailed\",\"type\":\"bool\"},{\"internalType\":\"uint48\",\"name\":\"validAfter\",\"type\":\"uint48\"},{\"internalType\":\"uint48\",\"name\":\"validUntil\",\"type\":\"uint48\"},{\"internalType\":\"bytes\",\"name\":\"paymasterContext\",\"type\":\"bytes\"}]},{\"internalType\":\"structIStakeManager.StakeInfo\",\"name\":\"senderInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unstakeDelaySec\",\"type\":\"uint256\"}]},{\"internalType\":\"structIStakeManager.StakeInfo\",\"name\":\"factoryInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unstakeDelaySec\",\"type\":\"uint256\"}]},{\"internalType\":\"structIStakeManager.StakeInfo\",\"name\":\"paymasterInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unstakeDelaySec\",\"type\":\"uint256\"}]}],\"name\":\"ValidationResult\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"structIEntryPoint.ReturnInfo\",\"name\":\"returnInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"uint256\",\"name\":\"preOpGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"prefund\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"sigFailed\",\"type\":\"bool\"},{\"internalType\":\"uint48\",\"name\":\"validAfter\",\"type\":\"uint48\"},{\"internalType\":\"uint48\",\"name\":\"validUntil\",\"type\":\"uint48\"},{\"internalType\":\"bytes\",\"name\":\"paymasterContext\",\"type\":\"bytes\"}]},{\"internalType\":\"structIStakeManager.StakeInfo\",\"name\":\"senderInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unstakeDelaySec\",\"type\":\"uint256\"}]},{\"internalType\":\"structIStakeManager.StakeInfo\",\"name\":\"factoryInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unstakeDelaySec\",\"type\":\"uint256\"}]},{\"internalType\":\"structIStakeManager.StakeInfo\",\"name\":\"paymasterInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unstakeDelaySec\",\"type\":\"uint256\"}]},{\"internalType\":\"structIEntryPoint.AggregatorStakeInfo\",\"name\":\"aggregatorInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"aggregator\",\"type\":\"address\"},{\"internalType\":\"structIStakeManager.StakeInfo\",\"name\":\"stakeInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unstakeDelaySec\",\"type\":\"uint256\"}]}]}],\"name\":\"ValidationResultWithAggregation\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"userOpHash\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"factory\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"paymaster\",\"type\":\"address\"}],\"name\":\"AccountDeployed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"BeforeExecution\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"totalDeposit\",\"type\":\"uint256\"}],\"name\":\"Deposited\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"aggregator\",\"type\":\"address\"}],\"name\":\"SignatureAggregatorChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"totalStaked\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"unstakeDelaySec\",\"type\":\"uint256\"}],\"name\":\"StakeLocked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"withdrawTime\",\"type\":\"uint256\"}],\"name\":\"StakeUnlocked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"withdrawAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"StakeWithdrawn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"userOpHash\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"paymaster\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"actualGasCost\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"actualGasUsed\",\"type\":\"uint256\"}],\"name\":\"UserOperationEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"userOpHash\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"revertReason\",\"type\":\"bytes\"}],\"name\":\"UserOperationRevertReason\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"withdrawAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Withdrawn\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"SIG_VALIDATION_FAILED\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"},{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"}],\"name\":\"_validateSenderAndPaymaster\",\"outputs\":[],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"unstakeDelaySec\",\"type\":\"uint32\"}],\"name\":\"addStake\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"depositTo\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"deposits\",\"outputs\":[{\"internalType\":\"uint112\",\"name\":\"deposit\",\"type\":\"uint112\"},{\"internalType\":\"bool\",\"name\":\"staked\",\"type\":\"bool\"},{\"internalType\":\"uint112\",\"name\":\"stake\",\"type\":\"uint112\"},{\"internalType\":\"uint32\",\"name\":\"unstakeDelaySec\",\"type\":\"uint32\"},{\"internalType\":\"uint48\",\"name\":\"withdrawTime\",\"type\":\"uint48\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getDepositInfo\",\"outputs\":[{\"internalType\":\"structIStakeManager.DepositInfo\",\"name\":\"info\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"uint112\",\"name\":\"deposit\",\"type\":\"uint112\"},{\"internalType\":\"bool\",\"name\":\"staked\",\"type\":\"bool\"},{\"internalType\":\"uint112\",\"name\":\"stake\",\"type\":\"uint112\"},{\"internalType\":\"uint32\",\"name\":\"unstakeDelaySec\",\"type\":\"uint32\"},{\"internalType\":\"uint48\",\"name\":\"withdrawTime\",\"type\":\"uint48\"}]}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint192\",\"name\":\"key\",\"type\":\"uint192\"}],\"name\":\"getNonce\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"}],\"name\":\"getSenderAddress\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structUserOperation\",\"name\":\"userOp\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"callGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"verificationGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"preVerificationGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxPriorityFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}]}],\"name\":\"getUserOpHash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structIEntryPoint.UserOpsPerAggregator[]\",\"name\":\"opsPerAggregator\",\"type\":\"tuple[]\",\"components\":[{\"internalType\":\"structUserOperation[]\",\"name\":\"userOps\",\"type\":\"tuple[]\",\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"callGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"verificationGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"preVerificationGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxPriorityFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}]},{\"internalType\":\"contractIAggregator\",\"name\":\"aggregator\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}]},{\"internalType\":\"addresspayable\",\"name\":\"beneficiary\",\"type\":\"address\"}],\"name\":\"handleAggregatedOps\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structUserOperation[]\",\"name\":\"ops\",\"type\":\"tuple[]\",\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"callGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"verificationGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"preVerificationGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxPriorityFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}]},{\"internalType\":\"addresspayable\",\"name\":\"beneficiary\",\"type\":\"address\"}],\"name\":\"handleOps\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint192\",\"name\":\"key\",\"type\":\"uint192\"}],\"name\":\"incrementNonce\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"structEntryPoint.UserOpInfo\",\"name\":\"opInfo\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"structEntryPoint.MemoryUserOp\",\"name\":\"mUserOp\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"callGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"verificationGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"preVerificationGas\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"paymaster\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"maxFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxPriorityFeePerGas\",\"type\":\"uint256\"}]},{\"internalType\":\"bytes32\",\"name\":\"userOpHash\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"prefund\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"contextOffset\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"preOpGas\",\"type\":\"uint256\"}]},{\"internalType\":\"bytes\",\"name\":\"context\",\"type\":\"bytes\"}],\"name\":\"innerHandleOp\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"actualGasCost\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint192\",\"name\":\"\",\"type\":\"uint192\"}],\"name\":\"nonceSequenceNumber\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structUserOperation\",\"name\":\"op\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"callGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"verificationGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"preVerificationGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxPriorityFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}]},{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"targetCallData\",\"type\":\"bytes\"}],\"name\":\"simulateHandleOp\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structUserOperation\",\"name\":\"userOp\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"callGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"verificationGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"preVerificationGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxPriorityFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}]}],\"name\":\"simulateValidation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unlockStake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"addresspayable\",\"name\":\"withdrawAddress\",\"type\":\"address\"}],\"name\":\"withdrawStake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"addresspayable\",\"name\":\"withdrawAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"withdrawAmount\",\"type\":\"uint256\"}],\"name\":\"withdrawTo\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+}
+
+// EntryPointV06ABI is the input ABI used to generate the binding from.
+// Deprecated: Use EntryPointV06MetaData.ABI instead.
+var EntryPointV06ABI = EntryPointV06MetaData.ABI
+
+// EntryPointV06 is an auto generated Go binding around an Ethereum contract.
+type EntryPointV06 struct {
+	EntryPointV06Caller     // Read-only binding to the contract
+	EntryPointV06Transactor // Write-only binding to the contract
+	EntryPointV06Filterer   // Log filterer for contract events
+}
+
+// EntryPointV06Caller is an auto generated read-only Go binding around an Ethereum contract.
+type EntryPointV06Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EntryPointV06Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type EntryPointV06Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EntryPointV06Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type EntryPointV06Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// EntryPointV06Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type EntryPointV06Session struct {
+	Contract     *EntryPointV06    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// EntryPointV06CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type EntryPointV06CallerSession struct {
+	Contract *EntryPointV06Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// EntryPointV06TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type EntryPointV06TransactorSession struct {
+	Contract     *EntryPointV06Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// EntryPointV06Raw is an auto generated low-level Go binding around an Ethereum contract.
+type EntryPointV06Raw struct {
+	Contract *EntryPointV06 // Generic contract binding to access the raw methods on
+}
+
+// EntryPointV06CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type EntryPointV06CallerRaw struct {
+	Contract *EntryPointV06Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// EntryPointV06TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type EntryPointV06TransactorRaw struct {
+	Contract *EntryPointV06Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewEntryPointV06 creates a new instance of EntryPointV06, bound to a specific deployed contract.
+func NewEntryPointV06(address common.Address, backend bind.ContractBackend) (*EntryPointV06, error) {
+	contract, err := bindEntryPointV06(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06{EntryPointV06Caller: EntryPointV06Caller{contract: contract}, EntryPointV06Transactor: EntryPointV06Transactor{contract: contract}, EntryPointV06Filterer: EntryPointV06Filterer{contract: contract}}, nil
+}
+
+// NewEntryPointV06Caller creates a new read-only instance of EntryPointV06, bound to a specific deployed contract.
+func NewEntryPointV06Caller(address common.Address, caller bind.ContractCaller) (*EntryPointV06Caller, error) {
+	contract, err := bindEntryPointV06(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06Caller{contract: contract}, nil
+}
+
+// NewEntryPointV06Transactor creates a new write-only instance of EntryPointV06, bound to a specific deployed contract.
+func NewEntryPointV06Transactor(address common.Address, transactor bind.ContractTransactor) (*EntryPointV06Transactor, error) {
+	contract, err := bindEntryPointV06(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06Transactor{contract: contract}, nil
+}
+
+// NewEntryPointV06Filterer creates a new log filterer instance of EntryPointV06, bound to a specific deployed contract.
+func NewEntryPointV06Filterer(address common.Address, filterer bind.ContractFilterer) (*EntryPointV06Filterer, error) {
+	contract, err := bindEntryPointV06(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06Filterer{contract: contract}, nil
+}
+
+// bindEntryPointV06 binds a generic wrapper to an already deployed contract.
+func bindEntryPointV06(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := EntryPointV06MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_EntryPointV06 *EntryPointV06Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _EntryPointV06.Contract.EntryPointV06Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_EntryPointV06 *EntryPointV06Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.EntryPointV06Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_EntryPointV06 *EntryPointV06Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.EntryPointV06Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_EntryPointV06 *EntryPointV06CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _EntryPointV06.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_EntryPointV06 *EntryPointV06TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_EntryPointV06 *EntryPointV06TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.contract.Transact(opts, method, params...)
+}
+
+// SIGVALIDATIONFAILED is a free data retrieval call binding the contract method 0x8f41ec5a.
+//
+// Solidity: function SIG_VALIDATION_FAILED() view returns(uint256)
+func (_EntryPointV06 *EntryPointV06Caller) SIGVALIDATIONFAILED(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _EntryPointV06.contract.Call(opts, &out, "SIG_VALIDATION_FAILED")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SIGVALIDATIONFAILED is a free data retrieval call binding the contract method 0x8f41ec5a.
+//
+// Solidity: function SIG_VALIDATION_FAILED() view returns(uint256)
+func (_EntryPointV06 *EntryPointV06Session) SIGVALIDATIONFAILED() (*big.Int, error) {
+	return _EntryPointV06.Contract.SIGVALIDATIONFAILED(&_EntryPointV06.CallOpts)
+}
+
+// SIGVALIDATIONFAILED is a free data retrieval call binding the contract method 0x8f41ec5a.
+//
+// Solidity: function SIG_VALIDATION_FAILED() view returns(uint256)
+func (_EntryPointV06 *EntryPointV06CallerSession) SIGVALIDATIONFAILED() (*big.Int, error) {
+	return _EntryPointV06.Contract.SIGVALIDATIONFAILED(&_EntryPointV06.CallOpts)
+}
+
+// ValidateSenderAndPaymaster is a free data retrieval call binding the contract method 0x957122ab.
+//
+// Solidity: function _validateSenderAndPaymaster(bytes initCode, address sender, bytes paymasterAndData) view returns()
+func (_EntryPointV06 *EntryPointV06Caller) ValidateSenderAndPaymaster(opts *bind.CallOpts, initCode []byte, sender common.Address, paymasterAndData []byte) error {
+	var out []interface{}
+	err := _EntryPointV06.contract.Call(opts, &out, "_validateSenderAndPaymaster", initCode, sender, paymasterAndData)
+
+	if err != nil {
+		return err
+	}
+
+	return err
+
+}
+
+// ValidateSenderAndPaymaster is a free data retrieval call binding the contract method 0x957122ab.
+//
+// Solidity: function _validateSenderAndPaymaster(bytes initCode, address sender, bytes paymasterAndData) view returns()
+func (_EntryPointV06 *EntryPointV06Session) ValidateSenderAndPaymaster(initCode []byte, sender common.Address, paymasterAndData []byte) error {
+	return _EntryPointV06.Contract.ValidateSenderAndPaymaster(&_EntryPointV06.CallOpts, initCode, sender, paymasterAndData)
+}
+
+// ValidateSenderAndPaymaster is a free data retrieval call binding the contract method 0x957122ab.
+//
+// Solidity: function _validateSenderAndPaymaster(bytes initCode, address sender, bytes paymasterAndData) view returns()
+func (_EntryPointV06 *EntryPointV06CallerSession) ValidateSenderAndPaymaster(initCode []byte, sender common.Address, paymasterAndData []byte) error {
+	return _EntryPointV06.Contract.ValidateSenderAndPaymaster(&_EntryPointV06.CallOpts, initCode, sender, paymasterAndData)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_EntryPointV06 *EntryPointV06Caller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _EntryPointV06.contract.Call(opts, &out, "balanceOf", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_EntryPointV06 *EntryPointV06Session) BalanceOf(account common.Address) (*big.Int, error) {
+	return _EntryPointV06.Contract.BalanceOf(&_EntryPointV06.CallOpts, account)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_EntryPointV06 *EntryPointV06CallerSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _EntryPointV06.Contract.BalanceOf(&_EntryPointV06.CallOpts, account)
+}
+
+// Deposits is a free data retrieval call binding the contract method 0xfc7e286d.
+//
+// Solidity: function deposits(address ) view returns(uint112 deposit, bool staked, uint112 stake, uint32 unstakeDelaySec, uint48 withdrawTime)
+func (_EntryPointV06 *EntryPointV06Caller) Deposits(opts *bind.CallOpts, arg0 common.Address) (struct {
+	Deposit         *big.Int
+	Staked          bool
+	Stake           *big.Int
+	UnstakeDelaySec uint32
+	WithdrawTime    *big.Int
+}, error) {
+	var out []interface{}
+	err := _EntryPointV06.contract.Call(opts, &out, "deposits", arg0)
+
+	outstruct := new(struct {
+		Deposit         *big.Int
+		Staked          bool
+		Stake           *big.Int
+		UnstakeDelaySec uint32
+		WithdrawTime    *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Deposit = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Staked = *abi.ConvertType(out[1], new(bool)).(*bool)
+	outstruct.Stake = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.UnstakeDelaySec = *abi.ConvertType(out[3], new(uint32)).(*uint32)
+	outstruct.WithdrawTime = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Deposits is a free data retrieval call binding the contract method 0xfc7e286d.
+//
+// Solidity: function deposits(address ) view returns(uint112 deposit, bool staked, uint112 stake, uint32 unstakeDelaySec, uint48 withdrawTime)
+func (_EntryPointV06 *EntryPointV06Session) Deposits(arg0 common.Address) (struct {
+	Deposit         *big.Int
+	Staked          bool
+	Stake           *big.Int
+	UnstakeDelaySec uint32
+	WithdrawTime    *big.Int
+}, error) {
+	return _EntryPointV06.Contract.Deposits(&_EntryPointV06.CallOpts, arg0)
+}
+
+// Deposits is a free data retrieval call binding the contract method 0xfc7e286d.
+//
+// Solidity: function deposits(address ) view returns(uint112 deposit, bool staked, uint112 stake, uint32 unstakeDelaySec, uint48 withdrawTime)
+func (_EntryPointV06 *EntryPointV06CallerSession) Deposits(arg0 common.Address) (struct {
+	Deposit         *big.Int
+	Staked          bool
+	Stake           *big.Int
+	UnstakeDelaySec uint32
+	WithdrawTime    *big.Int
+}, error) {
+	return _EntryPointV06.Contract.Deposits(&_EntryPointV06.CallOpts, arg0)
+}
+
+// GetDepositInfo is a free data retrieval call binding the contract method 0x5287ce12.
+//
+// Solidity: function getDepositInfo(address account) view returns((uint112,bool,uint112,uint32,uint48) info)
+func (_EntryPointV06 *EntryPointV06Caller) GetDepositInfo(opts *bind.CallOpts, account common.Address) (IStakeManagerDepositInfo, error) {
+	var out []interface{}
+	err := _EntryPointV06.contract.Call(opts, &out, "getDepositInfo", account)
+
+	if err != nil {
+		return *new(IStakeManagerDepositInfo), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(IStakeManagerDepositInfo)).(*IStakeManagerDepositInfo)
+
+	return out0, err
+
+}
+
+// GetDepositInfo is a free data retrieval call binding the contract method 0x5287ce12.
+//
+// Solidity: function getDepositInfo(address account) view returns((uint112,bool,uint112,uint32,uint48) info)
+func (_EntryPointV06 *EntryPointV06Session) GetDepositInfo(account common.Address) (IStakeManagerDepositInfo, error) {
+	return _EntryPointV06.Contract.GetDepositInfo(&_EntryPointV06.CallOpts, account)
+}
+
+// GetDepositInfo is a free data retrieval call binding the contract method 0x5287ce12.
+//
+// Solidity: function getDepositInfo(address account) view returns((uint112,bool,uint112,uint32,uint48) info)
+func (_EntryPointV06 *EntryPointV06CallerSession) GetDepositInfo(account common.Address) (IStakeManagerDepositInfo, error) {
+	return _EntryPointV06.Contract.GetDepositInfo(&_EntryPointV06.CallOpts, account)
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x35567e1a.
+//
+// Solidity: function getNonce(address sender, uint192 key) view returns(uint256 nonce)
+func (_EntryPointV06 *EntryPointV06Caller) GetNonce(opts *bind.CallOpts, sender common.Address, key *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _EntryPointV06.contract.Call(opts, &out, "getNonce", sender, key)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x35567e1a.
+//
+// Solidity: function getNonce(address sender, uint192 key) view returns(uint256 nonce)
+func (_EntryPointV06 *EntryPointV06Session) GetNonce(sender common.Address, key *big.Int) (*big.Int, error) {
+	return _EntryPointV06.Contract.GetNonce(&_EntryPointV06.CallOpts, sender, key)
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x35567e1a.
+//
+// Solidity: function getNonce(address sender, uint192 key) view returns(uint256 nonce)
+func (_EntryPointV06 *EntryPointV06CallerSession) GetNonce(sender common.Address, key *big.Int) (*big.Int, error) {
+	return _EntryPointV06.Contract.GetNonce(&_EntryPointV06.CallOpts, sender, key)
+}
+
+// GetUserOpHash is a free data retrieval call binding the contract method 0xa6193531.
+//
+// Solidity: function getUserOpHash((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp) view returns(bytes32)
+func (_EntryPointV06 *EntryPointV06Caller) GetUserOpHash(opts *bind.CallOpts, userOp UserOperation) ([32]byte, error) {
+	var out []interface{}
+	err := _EntryPointV06.contract.Call(opts, &out, "getUserOpHash", userOp)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetUserOpHash is a free data retrieval call binding the contract method 0xa6193531.
+//
+// Solidity: function getUserOpHash((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp) view returns(bytes32)
+func (_EntryPointV06 *EntryPointV06Session) GetUserOpHash(userOp UserOperation) ([32]byte, error) {
+	return _EntryPointV06.Contract.GetUserOpHash(&_EntryPointV06.CallOpts, userOp)
+}
+
+// GetUserOpHash is a free data retrieval call binding the contract method 0xa6193531.
+//
+// Solidity: function getUserOpHash((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp) view returns(bytes32)
+func (_EntryPointV06 *EntryPointV06CallerSession) GetUserOpHash(userOp UserOperation) ([32]byte, error) {
+	return _EntryPointV06.Contract.GetUserOpHash(&_EntryPointV06.CallOpts, userOp)
+}
+
+// NonceSequenceNumber is a free data retrieval call binding the contract method 0x1b2e01b8.
+//
+// Solidity: function nonceSequenceNumber(address , uint192 ) view returns(uint256)
+func (_EntryPointV06 *EntryPointV06Caller) NonceSequenceNumber(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _EntryPointV06.contract.Call(opts, &out, "nonceSequenceNumber", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NonceSequenceNumber is a free data retrieval call binding the contract method 0x1b2e01b8.
+//
+// Solidity: function nonceSequenceNumber(address , uint192 ) view returns(uint256)
+func (_EntryPointV06 *EntryPointV06Session) NonceSequenceNumber(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _EntryPointV06.Contract.NonceSequenceNumber(&_EntryPointV06.CallOpts, arg0, arg1)
+}
+
+// NonceSequenceNumber is a free data retrieval call binding the contract method 0x1b2e01b8.
+//
+// Solidity: function nonceSequenceNumber(address , uint192 ) view returns(uint256)
+func (_EntryPointV06 *EntryPointV06CallerSession) NonceSequenceNumber(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _EntryPointV06.Contract.NonceSequenceNumber(&_EntryPointV06.CallOpts, arg0, arg1)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x0396cb60.
+//
+// Solidity: function addStake(uint32 unstakeDelaySec) payable returns()
+func (_EntryPointV06 *EntryPointV06Transactor) AddStake(opts *bind.TransactOpts, unstakeDelaySec uint32) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "addStake", unstakeDelaySec)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x0396cb60.
+//
+// Solidity: function addStake(uint32 unstakeDelaySec) payable returns()
+func (_EntryPointV06 *EntryPointV06Session) AddStake(unstakeDelaySec uint32) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.AddStake(&_EntryPointV06.TransactOpts, unstakeDelaySec)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x0396cb60.
+//
+// Solidity: function addStake(uint32 unstakeDelaySec) payable returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) AddStake(unstakeDelaySec uint32) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.AddStake(&_EntryPointV06.TransactOpts, unstakeDelaySec)
+}
+
+// DepositTo is a paid mutator transaction binding the contract method 0xb760faf9.
+//
+// Solidity: function depositTo(address account) payable returns()
+func (_EntryPointV06 *EntryPointV06Transactor) DepositTo(opts *bind.TransactOpts, account common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "depositTo", account)
+}
+
+// DepositTo is a paid mutator transaction binding the contract method 0xb760faf9.
+//
+// Solidity: function depositTo(address account) payable returns()
+func (_EntryPointV06 *EntryPointV06Session) DepositTo(account common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.DepositTo(&_EntryPointV06.TransactOpts, account)
+}
+
+// DepositTo is a paid mutator transaction binding the contract method 0xb760faf9.
+//
+// Solidity: function depositTo(address account) payable returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) DepositTo(account common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.DepositTo(&_EntryPointV06.TransactOpts, account)
+}
+
+// GetSenderAddress is a paid mutator transaction binding the contract method 0x9b249f69.
+//
+// Solidity: function getSenderAddress(bytes initCode) returns()
+func (_EntryPointV06 *EntryPointV06Transactor) GetSenderAddress(opts *bind.TransactOpts, initCode []byte) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "getSenderAddress", initCode)
+}
+
+// GetSenderAddress is a paid mutator transaction binding the contract method 0x9b249f69.
+//
+// Solidity: function getSenderAddress(bytes initCode) returns()
+func (_EntryPointV06 *EntryPointV06Session) GetSenderAddress(initCode []byte) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.GetSenderAddress(&_EntryPointV06.TransactOpts, initCode)
+}
+
+// GetSenderAddress is a paid mutator transaction binding the contract method 0x9b249f69.
+//
+// Solidity: function getSenderAddress(bytes initCode) returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) GetSenderAddress(initCode []byte) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.GetSenderAddress(&_EntryPointV06.TransactOpts, initCode)
+}
+
+// HandleAggregatedOps is a paid mutator transaction binding the contract method 0x4b1d7cf5.
+//
+// Solidity: function handleAggregatedOps(((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[],address,bytes)[] opsPerAggregator, address beneficiary) returns()
+func (_EntryPointV06 *EntryPointV06Transactor) HandleAggregatedOps(opts *bind.TransactOpts, opsPerAggregator []IEntryPointUserOpsPerAggregator, beneficiary common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "handleAggregatedOps", opsPerAggregator, beneficiary)
+}
+
+// HandleAggregatedOps is a paid mutator transaction binding the contract method 0x4b1d7cf5.
+//
+// Solidity: function handleAggregatedOps(((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[],address,bytes)[] opsPerAggregator, address beneficiary) returns()
+func (_EntryPointV06 *EntryPointV06Session) HandleAggregatedOps(opsPerAggregator []IEntryPointUserOpsPerAggregator, beneficiary common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.HandleAggregatedOps(&_EntryPointV06.TransactOpts, opsPerAggregator, beneficiary)
+}
+
+// HandleAggregatedOps is a paid mutator transaction binding the contract method 0x4b1d7cf5.
+//
+// Solidity: function handleAggregatedOps(((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[],address,bytes)[] opsPerAggregator, address beneficiary) returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) HandleAggregatedOps(opsPerAggregator []IEntryPointUserOpsPerAggregator, beneficiary common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.HandleAggregatedOps(&_EntryPointV06.TransactOpts, opsPerAggregator, beneficiary)
+}
+
+// HandleOps is a paid mutator transaction binding the contract method 0x1fad948c.
+//
+// Solidity: function handleOps((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[] ops, address beneficiary) returns()
+func (_EntryPointV06 *EntryPointV06Transactor) HandleOps(opts *bind.TransactOpts, ops []UserOperation, beneficiary common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "handleOps", ops, beneficiary)
+}
+
+// HandleOps is a paid mutator transaction binding the contract method 0x1fad948c.
+//
+// Solidity: function handleOps((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[] ops, address beneficiary) returns()
+func (_EntryPointV06 *EntryPointV06Session) HandleOps(ops []UserOperation, beneficiary common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.HandleOps(&_EntryPointV06.TransactOpts, ops, beneficiary)
+}
+
+// HandleOps is a paid mutator transaction binding the contract method 0x1fad948c.
+//
+// Solidity: function handleOps((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes)[] ops, address beneficiary) returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) HandleOps(ops []UserOperation, beneficiary common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.HandleOps(&_EntryPointV06.TransactOpts, ops, beneficiary)
+}
+
+// IncrementNonce is a paid mutator transaction binding the contract method 0x0bd28e3b.
+//
+// Solidity: function incrementNonce(uint192 key) returns()
+func (_EntryPointV06 *EntryPointV06Transactor) IncrementNonce(opts *bind.TransactOpts, key *big.Int) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "incrementNonce", key)
+}
+
+// IncrementNonce is a paid mutator transaction binding the contract method 0x0bd28e3b.
+//
+// Solidity: function incrementNonce(uint192 key) returns()
+func (_EntryPointV06 *EntryPointV06Session) IncrementNonce(key *big.Int) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.IncrementNonce(&_EntryPointV06.TransactOpts, key)
+}
+
+// IncrementNonce is a paid mutator transaction binding the contract method 0x0bd28e3b.
+//
+// Solidity: function incrementNonce(uint192 key) returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) IncrementNonce(key *big.Int) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.IncrementNonce(&_EntryPointV06.TransactOpts, key)
+}
+
+// InnerHandleOp is a paid mutator transaction binding the contract method 0x1d732756.
+//
+// Solidity: function innerHandleOp(bytes callData, ((address,uint256,uint256,uint256,uint256,address,uint256,uint256),bytes32,uint256,uint256,uint256) opInfo, bytes context) returns(uint256 actualGasCost)
+func (_EntryPointV06 *EntryPointV06Transactor) InnerHandleOp(opts *bind.TransactOpts, callData []byte, opInfo EntryPointUserOpInfo, context []byte) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "innerHandleOp", callData, opInfo, context)
+}
+
+// InnerHandleOp is a paid mutator transaction binding the contract method 0x1d732756.
+//
+// Solidity: function innerHandleOp(bytes callData, ((address,uint256,uint256,uint256,uint256,address,uint256,uint256),bytes32,uint256,uint256,uint256) opInfo, bytes context) returns(uint256 actualGasCost)
+func (_EntryPointV06 *EntryPointV06Session) InnerHandleOp(callData []byte, opInfo EntryPointUserOpInfo, context []byte) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.InnerHandleOp(&_EntryPointV06.TransactOpts, callData, opInfo, context)
+}
+
+// InnerHandleOp is a paid mutator transaction binding the contract method 0x1d732756.
+//
+// Solidity: function innerHandleOp(bytes callData, ((address,uint256,uint256,uint256,uint256,address,uint256,uint256),bytes32,uint256,uint256,uint256) opInfo, bytes context) returns(uint256 actualGasCost)
+func (_EntryPointV06 *EntryPointV06TransactorSession) InnerHandleOp(callData []byte, opInfo EntryPointUserOpInfo, context []byte) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.InnerHandleOp(&_EntryPointV06.TransactOpts, callData, opInfo, context)
+}
+
+// SimulateHandleOp is a paid mutator transaction binding the contract method 0xd6383f94.
+//
+// Solidity: function simulateHandleOp((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) op, address target, bytes targetCallData) returns()
+func (_EntryPointV06 *EntryPointV06Transactor) SimulateHandleOp(opts *bind.TransactOpts, op UserOperation, target common.Address, targetCallData []byte) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "simulateHandleOp", op, target, targetCallData)
+}
+
+// SimulateHandleOp is a paid mutator transaction binding the contract method 0xd6383f94.
+//
+// Solidity: function simulateHandleOp((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) op, address target, bytes targetCallData) returns()
+func (_EntryPointV06 *EntryPointV06Session) SimulateHandleOp(op UserOperation, target common.Address, targetCallData []byte) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.SimulateHandleOp(&_EntryPointV06.TransactOpts, op, target, targetCallData)
+}
+
+// SimulateHandleOp is a paid mutator transaction binding the contract method 0xd6383f94.
+//
+// Solidity: function simulateHandleOp((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) op, address target, bytes targetCallData) returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) SimulateHandleOp(op UserOperation, target common.Address, targetCallData []byte) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.SimulateHandleOp(&_EntryPointV06.TransactOpts, op, target, targetCallData)
+}
+
+// SimulateValidation is a paid mutator transaction binding the contract method 0xee219423.
+//
+// Solidity: function simulateValidation((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp) returns()
+func (_EntryPointV06 *EntryPointV06Transactor) SimulateValidation(opts *bind.TransactOpts, userOp UserOperation) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "simulateValidation", userOp)
+}
+
+// SimulateValidation is a paid mutator transaction binding the contract method 0xee219423.
+//
+// Solidity: function simulateValidation((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp) returns()
+func (_EntryPointV06 *EntryPointV06Session) SimulateValidation(userOp UserOperation) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.SimulateValidation(&_EntryPointV06.TransactOpts, userOp)
+}
+
+// SimulateValidation is a paid mutator transaction binding the contract method 0xee219423.
+//
+// Solidity: function simulateValidation((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp) returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) SimulateValidation(userOp UserOperation) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.SimulateValidation(&_EntryPointV06.TransactOpts, userOp)
+}
+
+// UnlockStake is a paid mutator transaction binding the contract method 0xbb9fe6bf.
+//
+// Solidity: function unlockStake() returns()
+func (_EntryPointV06 *EntryPointV06Transactor) UnlockStake(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "unlockStake")
+}
+
+// UnlockStake is a paid mutator transaction binding the contract method 0xbb9fe6bf.
+//
+// Solidity: function unlockStake() returns()
+func (_EntryPointV06 *EntryPointV06Session) UnlockStake() (*types.Transaction, error) {
+	return _EntryPointV06.Contract.UnlockStake(&_EntryPointV06.TransactOpts)
+}
+
+// UnlockStake is a paid mutator transaction binding the contract method 0xbb9fe6bf.
+//
+// Solidity: function unlockStake() returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) UnlockStake() (*types.Transaction, error) {
+	return _EntryPointV06.Contract.UnlockStake(&_EntryPointV06.TransactOpts)
+}
+
+// WithdrawStake is a paid mutator transaction binding the contract method 0xc23a5cea.
+//
+// Solidity: function withdrawStake(address withdrawAddress) returns()
+func (_EntryPointV06 *EntryPointV06Transactor) WithdrawStake(opts *bind.TransactOpts, withdrawAddress common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "withdrawStake", withdrawAddress)
+}
+
+// WithdrawStake is a paid mutator transaction binding the contract method 0xc23a5cea.
+//
+// Solidity: function withdrawStake(address withdrawAddress) returns()
+func (_EntryPointV06 *EntryPointV06Session) WithdrawStake(withdrawAddress common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.WithdrawStake(&_EntryPointV06.TransactOpts, withdrawAddress)
+}
+
+// WithdrawStake is a paid mutator transaction binding the contract method 0xc23a5cea.
+//
+// Solidity: function withdrawStake(address withdrawAddress) returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) WithdrawStake(withdrawAddress common.Address) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.WithdrawStake(&_EntryPointV06.TransactOpts, withdrawAddress)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0x205c2878.
+//
+// Solidity: function withdrawTo(address withdrawAddress, uint256 withdrawAmount) returns()
+func (_EntryPointV06 *EntryPointV06Transactor) WithdrawTo(opts *bind.TransactOpts, withdrawAddress common.Address, withdrawAmount *big.Int) (*types.Transaction, error) {
+	return _EntryPointV06.contract.Transact(opts, "withdrawTo", withdrawAddress, withdrawAmount)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0x205c2878.
+//
+// Solidity: function withdrawTo(address withdrawAddress, uint256 withdrawAmount) returns()
+func (_EntryPointV06 *EntryPointV06Session) WithdrawTo(withdrawAddress common.Address, withdrawAmount *big.Int) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.WithdrawTo(&_EntryPointV06.TransactOpts, withdrawAddress, withdrawAmount)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0x205c2878.
+//
+// Solidity: function withdrawTo(address withdrawAddress, uint256 withdrawAmount) returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) WithdrawTo(withdrawAddress common.Address, withdrawAmount *big.Int) (*types.Transaction, error) {
+	return _EntryPointV06.Contract.WithdrawTo(&_EntryPointV06.TransactOpts, withdrawAddress, withdrawAmount)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_EntryPointV06 *EntryPointV06Transactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _EntryPointV06.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_EntryPointV06 *EntryPointV06Session) Receive() (*types.Transaction, error) {
+	return _EntryPointV06.Contract.Receive(&_EntryPointV06.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_EntryPointV06 *EntryPointV06TransactorSession) Receive() (*types.Transaction, error) {
+	return _EntryPointV06.Contract.Receive(&_EntryPointV06.TransactOpts)
+}
+
+// EntryPointV06AccountDeployedIterator is returned from FilterAccountDeployed and is used to iterate over the raw logs and unpacked data for AccountDeployed events raised by the EntryPointV06 contract.
+type EntryPointV06AccountDeployedIterator struct {
+	Event *EntryPointV06AccountDeployed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV06AccountDeployedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV06AccountDeployed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV06AccountDeployed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV06AccountDeployedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV06AccountDeployedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV06AccountDeployed represents a AccountDeployed event raised by the EntryPointV06 contract.
+type EntryPointV06AccountDeployed struct {
+	UserOpHash [32]byte
+	Sender     common.Address
+	Factory    common.Address
+	Paymaster  common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterAccountDeployed is a free log retrieval operation binding the contract event 0xd51a9c61267aa6196961883ecf5ff2da6619c37dac0fa92122513fb32c032d2d.
+//
+// Solidity: event AccountDeployed(bytes32 indexed userOpHash, address indexed sender, address factory, address paymaster)
+func (_EntryPointV06 *EntryPointV06Filterer) FilterAccountDeployed(opts *bind.FilterOpts, userOpHash [][32]byte, sender []common.Address) (*EntryPointV06AccountDeployedIterator, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.FilterLogs(opts, "AccountDeployed", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06AccountDeployedIterator{contract: _EntryPointV06.contract, event: "AccountDeployed", logs: logs, sub: sub}, nil
+}
+
+// WatchAccountDeployed is a free log subscription operation binding the contract event 0xd51a9c61267aa6196961883ecf5ff2da6619c37dac0fa92122513fb32c032d2d.
+//
+// Solidity: event AccountDeployed(bytes32 indexed userOpHash, address indexed sender, address factory, address paymaster)
+func (_EntryPointV06 *EntryPointV06Filterer) WatchAccountDeployed(opts *bind.WatchOpts, sink chan<- *EntryPointV06AccountDeployed, userOpHash [][32]byte, sender []common.Address) (event.Subscription, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.WatchLogs(opts, "AccountDeployed", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV06AccountDeployed)
+				if err := _EntryPointV06.contract.UnpackLog(event, "AccountDeployed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAccountDeployed is a log parse operation binding the contract event 0xd51a9c61267aa6196961883ecf5ff2da6619c37dac0fa92122513fb32c032d2d.
+//
+// Solidity: event AccountDeployed(bytes32 indexed userOpHash, address indexed sender, address factory, address paymaster)
+func (_EntryPointV06 *EntryPointV06Filterer) ParseAccountDeployed(log types.Log) (*EntryPointV06AccountDeployed, error) {
+	event := new(EntryPointV06AccountDeployed)
+	if err := _EntryPointV06.contract.UnpackLog(event, "AccountDeployed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV06BeforeExecutionIterator is returned from FilterBeforeExecution and is used to iterate over the raw logs and unpacked data for BeforeExecution events raised by the EntryPointV06 contract.
+type EntryPointV06BeforeExecutionIterator struct {
+	Event *EntryPointV06BeforeExecution // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV06BeforeExecutionIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV06BeforeExecution)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV06BeforeExecution)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV06BeforeExecutionIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV06BeforeExecutionIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV06BeforeExecution represents a BeforeExecution event raised by the EntryPointV06 contract.
+type EntryPointV06BeforeExecution struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterBeforeExecution is a free log retrieval operation binding the contract event 0xbb47ee3e183a558b1a2ff0874b079f3fc5478b7454eacf2bfc5af2ff5878f972.
+//
+// Solidity: event BeforeExecution()
+func (_EntryPointV06 *EntryPointV06Filterer) FilterBeforeExecution(opts *bind.FilterOpts) (*EntryPointV06BeforeExecutionIterator, error) {
+
+	logs, sub, err := _EntryPointV06.contract.FilterLogs(opts, "BeforeExecution")
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06BeforeExecutionIterator{contract: _EntryPointV06.contract, event: "BeforeExecution", logs: logs, sub: sub}, nil
+}
+
+// WatchBeforeExecution is a free log subscription operation binding the contract event 0xbb47ee3e183a558b1a2ff0874b079f3fc5478b7454eacf2bfc5af2ff5878f972.
+//
+// Solidity: event BeforeExecution()
+func (_EntryPointV06 *EntryPointV06Filterer) WatchBeforeExecution(opts *bind.WatchOpts, sink chan<- *EntryPointV06BeforeExecution) (event.Subscription, error) {
+
+	logs, sub, err := _EntryPointV06.contract.WatchLogs(opts, "BeforeExecution")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV06BeforeExecution)
+				if err := _EntryPointV06.contract.UnpackLog(event, "BeforeExecution", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBeforeExecution is a log parse operation binding the contract event 0xbb47ee3e183a558b1a2ff0874b079f3fc5478b7454eacf2bfc5af2ff5878f972.
+//
+// Solidity: event BeforeExecution()
+func (_EntryPointV06 *EntryPointV06Filterer) ParseBeforeExecution(log types.Log) (*EntryPointV06BeforeExecution, error) {
+	event := new(EntryPointV06BeforeExecution)
+	if err := _EntryPointV06.contract.UnpackLog(event, "BeforeExecution", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV06DepositedIterator is returned from FilterDeposited and is used to iterate over the raw logs and unpacked data for Deposited events raised by the EntryPointV06 contract.
+type EntryPointV06DepositedIterator struct {
+	Event *EntryPointV06Deposited // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV06DepositedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV06Deposited)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV06Deposited)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV06DepositedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV06DepositedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV06Deposited represents a Deposited event raised by the EntryPointV06 contract.
+type EntryPointV06Deposited struct {
+	Account      common.Address
+	TotalDeposit *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterDeposited is a free log retrieval operation binding the contract event 0x2da466a7b24304f47e87fa2e1e5a81b9831ce54fec19055ce277ca2f39ba42c4.
+//
+// Solidity: event Deposited(address indexed account, uint256 totalDeposit)
+func (_EntryPointV06 *EntryPointV06Filterer) FilterDeposited(opts *bind.FilterOpts, account []common.Address) (*EntryPointV06DepositedIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.FilterLogs(opts, "Deposited", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06DepositedIterator{contract: _EntryPointV06.contract, event: "Deposited", logs: logs, sub: sub}, nil
+}
+
+// WatchDeposited is a free log subscription operation binding the contract event 0x2da466a7b24304f47e87fa2e1e5a81b9831ce54fec19055ce277ca2f39ba42c4.
+//
+// Solidity: event Deposited(address indexed account, uint256 totalDeposit)
+func (_EntryPointV06 *EntryPointV06Filterer) WatchDeposited(opts *bind.WatchOpts, sink chan<- *EntryPointV06Deposited, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.WatchLogs(opts, "Deposited", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV06Deposited)
+				if err := _EntryPointV06.contract.UnpackLog(event, "Deposited", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDeposited is a log parse operation binding the contract event 0x2da466a7b24304f47e87fa2e1e5a81b9831ce54fec19055ce277ca2f39ba42c4.
+//
+// Solidity: event Deposited(address indexed account, uint256 totalDeposit)
+func (_EntryPointV06 *EntryPointV06Filterer) ParseDeposited(log types.Log) (*EntryPointV06Deposited, error) {
+	event := new(EntryPointV06Deposited)
+	if err := _EntryPointV06.contract.UnpackLog(event, "Deposited", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV06SignatureAggregatorChangedIterator is returned from FilterSignatureAggregatorChanged and is used to iterate over the raw logs and unpacked data for SignatureAggregatorChanged events raised by the EntryPointV06 contract.
+type EntryPointV06SignatureAggregatorChangedIterator struct {
+	Event *EntryPointV06SignatureAggregatorChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV06SignatureAggregatorChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV06SignatureAggregatorChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV06SignatureAggregatorChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV06SignatureAggregatorChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV06SignatureAggregatorChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV06SignatureAggregatorChanged represents a SignatureAggregatorChanged event raised by the EntryPointV06 contract.
+type EntryPointV06SignatureAggregatorChanged struct {
+	Aggregator common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterSignatureAggregatorChanged is a free log retrieval operation binding the contract event 0x575ff3acadd5ab348fe1855e217e0f3678f8d767d7494c9f9fefbee2e17cca4d.
+//
+// Solidity: event SignatureAggregatorChanged(address indexed aggregator)
+func (_EntryPointV06 *EntryPointV06Filterer) FilterSignatureAggregatorChanged(opts *bind.FilterOpts, aggregator []common.Address) (*EntryPointV06SignatureAggregatorChangedIterator, error) {
+
+	var aggregatorRule []interface{}
+	for _, aggregatorItem := range aggregator {
+		aggregatorRule = append(aggregatorRule, aggregatorItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.FilterLogs(opts, "SignatureAggregatorChanged", aggregatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06SignatureAggregatorChangedIterator{contract: _EntryPointV06.contract, event: "SignatureAggregatorChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchSignatureAggregatorChanged is a free log subscription operation binding the contract event 0x575ff3acadd5ab348fe1855e217e0f3678f8d767d7494c9f9fefbee2e17cca4d.
+//
+// Solidity: event SignatureAggregatorChanged(address indexed aggregator)
+func (_EntryPointV06 *EntryPointV06Filterer) WatchSignatureAggregatorChanged(opts *bind.WatchOpts, sink chan<- *EntryPointV06SignatureAggregatorChanged, aggregator []common.Address) (event.Subscription, error) {
+
+	var aggregatorRule []interface{}
+	for _, aggregatorItem := range aggregator {
+		aggregatorRule = append(aggregatorRule, aggregatorItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.WatchLogs(opts, "SignatureAggregatorChanged", aggregatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV06SignatureAggregatorChanged)
+				if err := _EntryPointV06.contract.UnpackLog(event, "SignatureAggregatorChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSignatureAggregatorChanged is a log parse operation binding the contract event 0x575ff3acadd5ab348fe1855e217e0f3678f8d767d7494c9f9fefbee2e17cca4d.
+//
+// Solidity: event SignatureAggregatorChanged(address indexed aggregator)
+func (_EntryPointV06 *EntryPointV06Filterer) ParseSignatureAggregatorChanged(log types.Log) (*EntryPointV06SignatureAggregatorChanged, error) {
+	event := new(EntryPointV06SignatureAggregatorChanged)
+	if err := _EntryPointV06.contract.UnpackLog(event, "SignatureAggregatorChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV06StakeLockedIterator is returned from FilterStakeLocked and is used to iterate over the raw logs and unpacked data for StakeLocked events raised by the EntryPointV06 contract.
+type EntryPointV06StakeLockedIterator struct {
+	Event *EntryPointV06StakeLocked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV06StakeLockedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV06StakeLocked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV06StakeLocked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV06StakeLockedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV06StakeLockedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV06StakeLocked represents a StakeLocked event raised by the EntryPointV06 contract.
+type EntryPointV06StakeLocked struct {
+	Account         common.Address
+	TotalStaked     *big.Int
+	UnstakeDelaySec *big.Int
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterStakeLocked is a free log retrieval operation binding the contract event 0xa5ae833d0bb1dcd632d98a8b70973e8516812898e19bf27b70071ebc8dc52c01.
+//
+// Solidity: event StakeLocked(address indexed account, uint256 totalStaked, uint256 unstakeDelaySec)
+func (_EntryPointV06 *EntryPointV06Filterer) FilterStakeLocked(opts *bind.FilterOpts, account []common.Address) (*EntryPointV06StakeLockedIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.FilterLogs(opts, "StakeLocked", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06StakeLockedIterator{contract: _EntryPointV06.contract, event: "StakeLocked", logs: logs, sub: sub}, nil
+}
+
+// WatchStakeLocked is a free log subscription operation binding the contract event 0xa5ae833d0bb1dcd632d98a8b70973e8516812898e19bf27b70071ebc8dc52c01.
+//
+// Solidity: event StakeLocked(address indexed account, uint256 totalStaked, uint256 unstakeDelaySec)
+func (_EntryPointV06 *EntryPointV06Filterer) WatchStakeLocked(opts *bind.WatchOpts, sink chan<- *EntryPointV06StakeLocked, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.WatchLogs(opts, "StakeLocked", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV06StakeLocked)
+				if err := _EntryPointV06.contract.UnpackLog(event, "StakeLocked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStakeLocked is a log parse operation binding the contract event 0xa5ae833d0bb1dcd632d98a8b70973e8516812898e19bf27b70071ebc8dc52c01.
+//
+// Solidity: event StakeLocked(address indexed account, uint256 totalStaked, uint256 unstakeDelaySec)
+func (_EntryPointV06 *EntryPointV06Filterer) ParseStakeLocked(log types.Log) (*EntryPointV06StakeLocked, error) {
+	event := new(EntryPointV06StakeLocked)
+	if err := _EntryPointV06.contract.UnpackLog(event, "StakeLocked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV06StakeUnlockedIterator is returned from FilterStakeUnlocked and is used to iterate over the raw logs and unpacked data for StakeUnlocked events raised by the EntryPointV06 contract.
+type EntryPointV06StakeUnlockedIterator struct {
+	Event *EntryPointV06StakeUnlocked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV06StakeUnlockedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV06StakeUnlocked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV06StakeUnlocked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV06StakeUnlockedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV06StakeUnlockedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV06StakeUnlocked represents a StakeUnlocked event raised by the EntryPointV06 contract.
+type EntryPointV06StakeUnlocked struct {
+	Account      common.Address
+	WithdrawTime *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterStakeUnlocked is a free log retrieval operation binding the contract event 0xfa9b3c14cc825c412c9ed81b3ba365a5b459439403f18829e572ed53a4180f0a.
+//
+// Solidity: event StakeUnlocked(address indexed account, uint256 withdrawTime)
+func (_EntryPointV06 *EntryPointV06Filterer) FilterStakeUnlocked(opts *bind.FilterOpts, account []common.Address) (*EntryPointV06StakeUnlockedIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.FilterLogs(opts, "StakeUnlocked", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06StakeUnlockedIterator{contract: _EntryPointV06.contract, event: "StakeUnlocked", logs: logs, sub: sub}, nil
+}
+
+// WatchStakeUnlocked is a free log subscription operation binding the contract event 0xfa9b3c14cc825c412c9ed81b3ba365a5b459439403f18829e572ed53a4180f0a.
+//
+// Solidity: event StakeUnlocked(address indexed account, uint256 withdrawTime)
+func (_EntryPointV06 *EntryPointV06Filterer) WatchStakeUnlocked(opts *bind.WatchOpts, sink chan<- *EntryPointV06StakeUnlocked, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.WatchLogs(opts, "StakeUnlocked", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV06StakeUnlocked)
+				if err := _EntryPointV06.contract.UnpackLog(event, "StakeUnlocked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStakeUnlocked is a log parse operation binding the contract event 0xfa9b3c14cc825c412c9ed81b3ba365a5b459439403f18829e572ed53a4180f0a.
+//
+// Solidity: event StakeUnlocked(address indexed account, uint256 withdrawTime)
+func (_EntryPointV06 *EntryPointV06Filterer) ParseStakeUnlocked(log types.Log) (*EntryPointV06StakeUnlocked, error) {
+	event := new(EntryPointV06StakeUnlocked)
+	if err := _EntryPointV06.contract.UnpackLog(event, "StakeUnlocked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV06StakeWithdrawnIterator is returned from FilterStakeWithdrawn and is used to iterate over the raw logs and unpacked data for StakeWithdrawn events raised by the EntryPointV06 contract.
+type EntryPointV06StakeWithdrawnIterator struct {
+	Event *EntryPointV06StakeWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV06StakeWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV06StakeWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV06StakeWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV06StakeWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV06StakeWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV06StakeWithdrawn represents a StakeWithdrawn event raised by the EntryPointV06 contract.
+type EntryPointV06StakeWithdrawn struct {
+	Account         common.Address
+	WithdrawAddress common.Address
+	Amount          *big.Int
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterStakeWithdrawn is a free log retrieval operation binding the contract event 0xb7c918e0e249f999e965cafeb6c664271b3f4317d296461500e71da39f0cbda3.
+//
+// Solidity: event StakeWithdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV06 *EntryPointV06Filterer) FilterStakeWithdrawn(opts *bind.FilterOpts, account []common.Address) (*EntryPointV06StakeWithdrawnIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.FilterLogs(opts, "StakeWithdrawn", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06StakeWithdrawnIterator{contract: _EntryPointV06.contract, event: "StakeWithdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchStakeWithdrawn is a free log subscription operation binding the contract event 0xb7c918e0e249f999e965cafeb6c664271b3f4317d296461500e71da39f0cbda3.
+//
+// Solidity: event StakeWithdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV06 *EntryPointV06Filterer) WatchStakeWithdrawn(opts *bind.WatchOpts, sink chan<- *EntryPointV06StakeWithdrawn, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.WatchLogs(opts, "StakeWithdrawn", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV06StakeWithdrawn)
+				if err := _EntryPointV06.contract.UnpackLog(event, "StakeWithdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStakeWithdrawn is a log parse operation binding the contract event 0xb7c918e0e249f999e965cafeb6c664271b3f4317d296461500e71da39f0cbda3.
+//
+// Solidity: event StakeWithdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV06 *EntryPointV06Filterer) ParseStakeWithdrawn(log types.Log) (*EntryPointV06StakeWithdrawn, error) {
+	event := new(EntryPointV06StakeWithdrawn)
+	if err := _EntryPointV06.contract.UnpackLog(event, "StakeWithdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV06UserOperationEventIterator is returned from FilterUserOperationEvent and is used to iterate over the raw logs and unpacked data for UserOperationEvent events raised by the EntryPointV06 contract.
+type EntryPointV06UserOperationEventIterator struct {
+	Event *EntryPointV06UserOperationEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV06UserOperationEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV06UserOperationEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV06UserOperationEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV06UserOperationEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV06UserOperationEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV06UserOperationEvent represents a UserOperationEvent event raised by the EntryPointV06 contract.
+type EntryPointV06UserOperationEvent struct {
+	UserOpHash    [32]byte
+	Sender        common.Address
+	Paymaster     common.Address
+	Nonce         *big.Int
+	Success       bool
+	ActualGasCost *big.Int
+	ActualGasUsed *big.Int
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterUserOperationEvent is a free log retrieval operation binding the contract event 0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f.
+//
+// Solidity: event UserOperationEvent(bytes32 indexed userOpHash, address indexed sender, address indexed paymaster, uint256 nonce, bool success, uint256 actualGasCost, uint256 actualGasUsed)
+func (_EntryPointV06 *EntryPointV06Filterer) FilterUserOperationEvent(opts *bind.FilterOpts, userOpHash [][32]byte, sender []common.Address, paymaster []common.Address) (*EntryPointV06UserOperationEventIterator, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var paymasterRule []interface{}
+	for _, paymasterItem := range paymaster {
+		paymasterRule = append(paymasterRule, paymasterItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.FilterLogs(opts, "UserOperationEvent", userOpHashRule, senderRule, paymasterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06UserOperationEventIterator{contract: _EntryPointV06.contract, event: "UserOperationEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchUserOperationEvent is a free log subscription operation binding the contract event 0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f.
+//
+// Solidity: event UserOperationEvent(bytes32 indexed userOpHash, address indexed sender, address indexed paymaster, uint256 nonce, bool success, uint256 actualGasCost, uint256 actualGasUsed)
+func (_EntryPointV06 *EntryPointV06Filterer) WatchUserOperationEvent(opts *bind.WatchOpts, sink chan<- *EntryPointV06UserOperationEvent, userOpHash [][32]byte, sender []common.Address, paymaster []common.Address) (event.Subscription, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var paymasterRule []interface{}
+	for _, paymasterItem := range paymaster {
+		paymasterRule = append(paymasterRule, paymasterItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.WatchLogs(opts, "UserOperationEvent", userOpHashRule, senderRule, paymasterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV06UserOperationEvent)
+				if err := _EntryPointV06.contract.UnpackLog(event, "UserOperationEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUserOperationEvent is a log parse operation binding the contract event 0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f.
+//
+// Solidity: event UserOperationEvent(bytes32 indexed userOpHash, address indexed sender, address indexed paymaster, uint256 nonce, bool success, uint256 actualGasCost, uint256 actualGasUsed)
+func (_EntryPointV06 *EntryPointV06Filterer) ParseUserOperationEvent(log types.Log) (*EntryPointV06UserOperationEvent, error) {
+	event := new(EntryPointV06UserOperationEvent)
+	if err := _EntryPointV06.contract.UnpackLog(event, "UserOperationEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV06UserOperationRevertReasonIterator is returned from FilterUserOperationRevertReason and is used to iterate over the raw logs and unpacked data for UserOperationRevertReason events raised by the EntryPointV06 contract.
+type EntryPointV06UserOperationRevertReasonIterator struct {
+	Event *EntryPointV06UserOperationRevertReason // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV06UserOperationRevertReasonIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV06UserOperationRevertReason)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV06UserOperationRevertReason)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV06UserOperationRevertReasonIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV06UserOperationRevertReasonIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV06UserOperationRevertReason represents a UserOperationRevertReason event raised by the EntryPointV06 contract.
+type EntryPointV06UserOperationRevertReason struct {
+	UserOpHash   [32]byte
+	Sender       common.Address
+	Nonce        *big.Int
+	RevertReason []byte
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterUserOperationRevertReason is a free log retrieval operation binding the contract event 0x1c4fada7374c0a9ee8841fc38afe82932dc0f8e69012e927f061a8bae611a201.
+//
+// Solidity: event UserOperationRevertReason(bytes32 indexed userOpHash, address indexed sender, uint256 nonce, bytes revertReason)
+func (_EntryPointV06 *EntryPointV06Filterer) FilterUserOperationRevertReason(opts *bind.FilterOpts, userOpHash [][32]byte, sender []common.Address) (*EntryPointV06UserOperationRevertReasonIterator, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.FilterLogs(opts, "UserOperationRevertReason", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06UserOperationRevertReasonIterator{contract: _EntryPointV06.contract, event: "UserOperationRevertReason", logs: logs, sub: sub}, nil
+}
+
+// WatchUserOperationRevertReason is a free log subscription operation binding the contract event 0x1c4fada7374c0a9ee8841fc38afe82932dc0f8e69012e927f061a8bae611a201.
+//
+// Solidity: event UserOperationRevertReason(bytes32 indexed userOpHash, address indexed sender, uint256 nonce, bytes revertReason)
+func (_EntryPointV06 *EntryPointV06Filterer) WatchUserOperationRevertReason(opts *bind.WatchOpts, sink chan<- *EntryPointV06UserOperationRevertReason, userOpHash [][32]byte, sender []common.Address) (event.Subscription, error) {
+
+	var userOpHashRule []interface{}
+	for _, userOpHashItem := range userOpHash {
+		userOpHashRule = append(userOpHashRule, userOpHashItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.WatchLogs(opts, "UserOperationRevertReason", userOpHashRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV06UserOperationRevertReason)
+				if err := _EntryPointV06.contract.UnpackLog(event, "UserOperationRevertReason", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUserOperationRevertReason is a log parse operation binding the contract event 0x1c4fada7374c0a9ee8841fc38afe82932dc0f8e69012e927f061a8bae611a201.
+//
+// Solidity: event UserOperationRevertReason(bytes32 indexed userOpHash, address indexed sender, uint256 nonce, bytes revertReason)
+func (_EntryPointV06 *EntryPointV06Filterer) ParseUserOperationRevertReason(log types.Log) (*EntryPointV06UserOperationRevertReason, error) {
+	event := new(EntryPointV06UserOperationRevertReason)
+	if err := _EntryPointV06.contract.UnpackLog(event, "UserOperationRevertReason", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EntryPointV06WithdrawnIterator is returned from FilterWithdrawn and is used to iterate over the raw logs and unpacked data for Withdrawn events raised by the EntryPointV06 contract.
+type EntryPointV06WithdrawnIterator struct {
+	Event *EntryPointV06Withdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EntryPointV06WithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EntryPointV06Withdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EntryPointV06Withdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EntryPointV06WithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EntryPointV06WithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EntryPointV06Withdrawn represents a Withdrawn event raised by the EntryPointV06 contract.
+type EntryPointV06Withdrawn struct {
+	Account         common.Address
+	WithdrawAddress common.Address
+	Amount          *big.Int
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawn is a free log retrieval operation binding the contract event 0xd1c19fbcd4551a5edfb66d43d2e337c04837afda3482b42bdf569a8fccdae5fb.
+//
+// Solidity: event Withdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV06 *EntryPointV06Filterer) FilterWithdrawn(opts *bind.FilterOpts, account []common.Address) (*EntryPointV06WithdrawnIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.FilterLogs(opts, "Withdrawn", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EntryPointV06WithdrawnIterator{contract: _EntryPointV06.contract, event: "Withdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawn is a free log subscription operation binding the contract event 0xd1c19fbcd4551a5edfb66d43d2e337c04837afda3482b42bdf569a8fccdae5fb.
+//
+// Solidity: event Withdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV06 *EntryPointV06Filterer) WatchWithdrawn(opts *bind.WatchOpts, sink chan<- *EntryPointV06Withdrawn, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _EntryPointV06.contract.WatchLogs(opts, "Withdrawn", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EntryPointV06Withdrawn)
+				if err := _EntryPointV06.contract.UnpackLog(event, "Withdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawn is a log parse operation binding the contract event 0xd1c19fbcd4551a5edfb66d43d2e337c04837afda3482b42bdf569a8fccdae5fb.
+//
+// Solidity: event Withdrawn(address indexed account, address withdrawAddress, uint256 amount)
+func (_EntryPointV06 *EntryPointV06Filterer) ParseWithdrawn(log types.Log) (*EntryPointV06Withdrawn, error) {
+	event := new(EntryPointV06Withdrawn)
+	if err := _EntryPointV06.contract.UnpackLog(event, "Withdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,774 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package paymasterv06
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// UserOperation is an auto generated low-level Go binding around an user-defined struct.
+type UserOperation struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+// PaymasterV06MetaData contains all meta data concerning the PaymasterV06 contract.
+var PaymasterV06MetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"contractIEntryPoint\",\"name\":\"_entryPoint\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_verifyingSigner\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"unstakeDelaySec\",\"type\":\"uint32\"}],\"name\":\"addStake\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"deposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"entryPoint\",\"outputs\":[{\"internalType\":\"contractIEntryPoint\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getDeposit\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structUserOperation\",\"name\":\"userOp\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"callGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"verificationGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"preVerificationGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxPriorityFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}]},{\"internalType\":\"uint48\",\"name\":\"validUntil\",\"type\":\"uint48\"},{\"internalType\":\"uint48\",\"name\":\"validAfter\",\"type\":\"uint48\"}],\"name\":\"getHash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"}],\"name\":\"parsePaymasterAndData\",\"outputs\":[{\"internalType\":\"uint48\",\"name\":\"validUntil\",\"type\":\"uint48\"},{\"internalType\":\"uint48\",\"name\":\"validAfter\",\"type\":\"uint48\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"enumIPaymaster.PostOpMode\",\"name\":\"mode\",\"type\":\"uint8\"},{\"internalType\":\"bytes\",\"name\":\"context\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"actualGasCost\",\"type\":\"uint256\"}],\"name\":\"postOp\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"senderNonce\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unlockStake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"structUserOperation\",\"name\":\"userOp\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"initCode\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"callData\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"callGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"verificationGasLimit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"preVerificationGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"maxPriorityFeePerGas\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"paymasterAndData\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}]},{\"internalType\":\"bytes32\",\"name\":\"userOpHash\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"maxCost\",\"type\":\"uint256\"}],\"name\":\"validatePaymasterUserOp\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"context\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"validationData\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"verifyingSigner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"addresspayable\",\"name\":\"withdrawAddress\",\"type\":\"address\"}],\"name\":\"withdrawStake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"addresspayable\",\"name\":\"withdrawAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"withdrawTo\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// PaymasterV06ABI is the input ABI used to generate the binding from.
+// Deprecated: Use PaymasterV06MetaData.ABI instead.
+var PaymasterV06ABI = PaymasterV06MetaData.ABI
+
+// PaymasterV06 is an auto generated Go binding around an Ethereum contract.
+type PaymasterV06 struct {
+	PaymasterV06Caller     // Read-only binding to the contract
+	PaymasterV06Transactor // Write-only binding to the contract
+	PaymasterV06Filterer   // Log filterer for contract events
+}
+
+// PaymasterV06Caller is an auto generated read-only Go binding around an Ethereum contract.
+type PaymasterV06Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PaymasterV06Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type PaymasterV06Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PaymasterV06Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type PaymasterV06Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PaymasterV06Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type PaymasterV06Session struct {
+	Contract     *PaymasterV06     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// PaymasterV06CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type PaymasterV06CallerSession struct {
+	Contract *PaymasterV06Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// PaymasterV06TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type PaymasterV06TransactorSession struct {
+	Contract     *PaymasterV06Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// PaymasterV06Raw is an auto generated low-level Go binding around an Ethereum contract.
+type PaymasterV06Raw struct {
+	Contract *PaymasterV06 // Generic contract binding to access the raw methods on
+}
+
+// PaymasterV06CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type PaymasterV06CallerRaw struct {
+	Contract *PaymasterV06Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// PaymasterV06TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type PaymasterV06TransactorRaw struct {
+	Contract *PaymasterV06Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewPaymasterV06 creates a new instance of PaymasterV06, bound to a specific deployed contract.
+func NewPaymasterV06(address common.Address, backend bind.ContractBackend) (*PaymasterV06, error) {
+	contract, err := bindPaymasterV06(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymasterV06{PaymasterV06Caller: PaymasterV06Caller{contract: contract}, PaymasterV06Transactor: PaymasterV06Transactor{contract: contract}, PaymasterV06Filterer: PaymasterV06Filterer{contract: contract}}, nil
+}
+
+// NewPaymasterV06Caller creates a new read-only instance of PaymasterV06, bound to a specific deployed contract.
+func NewPaymasterV06Caller(address common.Address, caller bind.ContractCaller) (*PaymasterV06Caller, error) {
+	contract, err := bindPaymasterV06(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymasterV06Caller{contract: contract}, nil
+}
+
+// NewPaymasterV06Transactor creates a new write-only instance of PaymasterV06, bound to a specific deployed contract.
+func NewPaymasterV06Transactor(address common.Address, transactor bind.ContractTransactor) (*PaymasterV06Transactor, error) {
+	contract, err := bindPaymasterV06(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymasterV06Transactor{contract: contract}, nil
+}
+
+// NewPaymasterV06Filterer creates a new log filterer instance of PaymasterV06, bound to a specific deployed contract.
+func NewPaymasterV06Filterer(address common.Address, filterer bind.ContractFilterer) (*PaymasterV06Filterer, error) {
+	contract, err := bindPaymasterV06(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymasterV06Filterer{contract: contract}, nil
+}
+
+// bindPaymasterV06 binds a generic wrapper to an already deployed contract.
+func bindPaymasterV06(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := PaymasterV06MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PaymasterV06 *PaymasterV06Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PaymasterV06.Contract.PaymasterV06Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PaymasterV06 *PaymasterV06Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.PaymasterV06Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PaymasterV06 *PaymasterV06Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.PaymasterV06Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PaymasterV06 *PaymasterV06CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PaymasterV06.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PaymasterV06 *PaymasterV06TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PaymasterV06 *PaymasterV06TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.contract.Transact(opts, method, params...)
+}
+
+// EntryPoint is a free data retrieval call binding the contract method 0xb0d691fe.
+//
+// Solidity: function entryPoint() view returns(address)
+func (_PaymasterV06 *PaymasterV06Caller) EntryPoint(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _PaymasterV06.contract.Call(opts, &out, "entryPoint")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// EntryPoint is a free data retrieval call binding the contract method 0xb0d691fe.
+//
+// Solidity: function entryPoint() view returns(address)
+func (_PaymasterV06 *PaymasterV06Session) EntryPoint() (common.Address, error) {
+	return _PaymasterV06.Contract.EntryPoint(&_PaymasterV06.CallOpts)
+}
+
+// EntryPoint is a free data retrieval call binding the contract method 0xb0d691fe.
+//
+// Solidity: function entryPoint() view returns(address)
+func (_PaymasterV06 *PaymasterV06CallerSession) EntryPoint() (common.Address, error) {
+	return _PaymasterV06.Contract.EntryPoint(&_PaymasterV06.CallOpts)
+}
+
+// GetDeposit is a free data retrieval call binding the contract method 0xc399ec88.
+//
+// Solidity: function getDeposit() view returns(uint256)
+func (_PaymasterV06 *PaymasterV06Caller) GetDeposit(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymasterV06.contract.Call(opts, &out, "getDeposit")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetDeposit is a free data retrieval call binding the contract method 0xc399ec88.
+//
+// Solidity: function getDeposit() view returns(uint256)
+func (_PaymasterV06 *PaymasterV06Session) GetDeposit() (*big.Int, error) {
+	return _PaymasterV06.Contract.GetDeposit(&_PaymasterV06.CallOpts)
+}
+
+// GetDeposit is a free data retrieval call binding the contract method 0xc399ec88.
+//
+// Solidity: function getDeposit() view returns(uint256)
+func (_PaymasterV06 *PaymasterV06CallerSession) GetDeposit() (*big.Int, error) {
+	return _PaymasterV06.Contract.GetDeposit(&_PaymasterV06.CallOpts)
+}
+
+// GetHash is a free data retrieval call binding the contract method 0x94e1fc19.
+//
+// Solidity: function getHash((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp, uint48 validUntil, uint48 validAfter) view returns(bytes32)
+func (_PaymasterV06 *PaymasterV06Caller) GetHash(opts *bind.CallOpts, userOp UserOperation, validUntil *big.Int, validAfter *big.Int) ([32]byte, error) {
+	var out []interface{}
+	err := _PaymasterV06.contract.Call(opts, &out, "getHash", userOp, validUntil, validAfter)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetHash is a free data retrieval call binding the contract method 0x94e1fc19.
+//
+// Solidity: function getHash((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp, uint48 validUntil, uint48 validAfter) view returns(bytes32)
+func (_PaymasterV06 *PaymasterV06Session) GetHash(userOp UserOperation, validUntil *big.Int, validAfter *big.Int) ([32]byte, error) {
+	return _PaymasterV06.Contract.GetHash(&_PaymasterV06.CallOpts, userOp, validUntil, validAfter)
+}
+
+// GetHash is a free data retrieval call binding the contract method 0x94e1fc19.
+//
+// Solidity: function getHash((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp, uint48 validUntil, uint48 validAfter) view returns(bytes32)
+func (_PaymasterV06 *PaymasterV06CallerSession) GetHash(userOp UserOperation, validUntil *big.Int, validAfter *big.Int) ([32]byte, error) {
+	return _PaymasterV06.Contract.GetHash(&_PaymasterV06.CallOpts, userOp, validUntil, validAfter)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_PaymasterV06 *PaymasterV06Caller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _PaymasterV06.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_PaymasterV06 *PaymasterV06Session) Owner() (common.Address, error) {
+	return _PaymasterV06.Contract.Owner(&_PaymasterV06.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_PaymasterV06 *PaymasterV06CallerSession) Owner() (common.Address, error) {
+	return _PaymasterV06.Contract.Owner(&_PaymasterV06.CallOpts)
+}
+
+// ParsePaymasterAndData is a free data retrieval call binding the contract method 0x94d4ad60.
+//
+// Solidity: function parsePaymasterAndData(bytes paymasterAndData) pure returns(uint48 validUntil, uint48 validAfter, bytes signature)
+func (_PaymasterV06 *PaymasterV06Caller) ParsePaymasterAndData(opts *bind.CallOpts, paymasterAndData []byte) (struct {
+	ValidUntil *big.Int
+	ValidAfter *big.Int
+	Signature  []byte
+}, error) {
+	var out []interface{}
+	err := _PaymasterV06.contract.Call(opts, &out, "parsePaymasterAndData", paymasterAndData)
+
+	outstruct := new(struct {
+		ValidUntil *big.Int
+		ValidAfter *big.Int
+		Signature  []byte
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.ValidUntil = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.ValidAfter = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Signature = *abi.ConvertType(out[2], new([]byte)).(*[]byte)
+
+	return *outstruct, err
+
+}
+
+// ParsePaymasterAndData is a free data retrieval call binding the contract method 0x94d4ad60.
+//
+// Solidity: function parsePaymasterAndData(bytes paymasterAndData) pure returns(uint48 validUntil, uint48 validAfter, bytes signature)
+func (_PaymasterV06 *PaymasterV06Session) ParsePaymasterAndData(paymasterAndData []byte) (struct {
+	ValidUntil *big.Int
+	ValidAfter *big.Int
+	Signature  []byte
+}, error) {
+	return _PaymasterV06.Contract.ParsePaymasterAndData(&_PaymasterV06.CallOpts, paymasterAndData)
+}
+
+// ParsePaymasterAndData is a free data retrieval call binding the contract method 0x94d4ad60.
+//
+// Solidity: function parsePaymasterAndData(bytes paymasterAndData) pure returns(uint48 validUntil, uint48 validAfter, bytes signature)
+func (_PaymasterV06 *PaymasterV06CallerSession) ParsePaymasterAndData(paymasterAndData []byte) (struct {
+	ValidUntil *big.Int
+	ValidAfter *big.Int
+	Signature  []byte
+}, error) {
+	return _PaymasterV06.Contract.ParsePaymasterAndData(&_PaymasterV06.CallOpts, paymasterAndData)
+}
+
+// SenderNonce is a free data retrieval call binding the contract method 0x9c90b443.
+//
+// Solidity: function senderNonce(address ) view returns(uint256)
+func (_PaymasterV06 *PaymasterV06Caller) SenderNonce(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymasterV06.contract.Call(opts, &out, "senderNonce", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SenderNonce is a free data retrieval call binding the contract method 0x9c90b443.
+//
+// Solidity: function senderNonce(address ) view returns(uint256)
+func (_PaymasterV06 *PaymasterV06Session) SenderNonce(arg0 common.Address) (*big.Int, error) {
+	return _PaymasterV06.Contract.SenderNonce(&_PaymasterV06.CallOpts, arg0)
+}
+
+// SenderNonce is a free data retrieval call binding the contract method 0x9c90b443.
+//
+// Solidity: function senderNonce(address ) view returns(uint256)
+func (_PaymasterV06 *PaymasterV06CallerSession) SenderNonce(arg0 common.Address) (*big.Int, error) {
+	return _PaymasterV06.Contract.SenderNonce(&_PaymasterV06.CallOpts, arg0)
+}
+
+// VerifyingSigner is a free data retrieval call binding the contract method 0x23d9ac9b.
+//
+// Solidity: function verifyingSigner() view returns(address)
+func (_PaymasterV06 *PaymasterV06Caller) VerifyingSigner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _PaymasterV06.contract.Call(opts, &out, "verifyingSigner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// VerifyingSigner is a free data retrieval call binding the contract method 0x23d9ac9b.
+//
+// Solidity: function verifyingSigner() view returns(address)
+func (_PaymasterV06 *PaymasterV06Session) VerifyingSigner() (common.Address, error) {
+	return _PaymasterV06.Contract.VerifyingSigner(&_PaymasterV06.CallOpts)
+}
+
+// VerifyingSigner is a free data retrieval call binding the contract method 0x23d9ac9b.
+//
+// Solidity: function verifyingSigner() view returns(address)
+func (_PaymasterV06 *PaymasterV06CallerSession) VerifyingSigner() (common.Address, error) {
+	return _PaymasterV06.Contract.VerifyingSigner(&_PaymasterV06.CallOpts)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x0396cb60.
+//
+// Solidity: function addStake(uint32 unstakeDelaySec) payable returns()
+func (_PaymasterV06 *PaymasterV06Transactor) AddStake(opts *bind.TransactOpts, unstakeDelaySec uint32) (*types.Transaction, error) {
+	return _PaymasterV06.contract.Transact(opts, "addStake", unstakeDelaySec)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x0396cb60.
+//
+// Solidity: function addStake(uint32 unstakeDelaySec) payable returns()
+func (_PaymasterV06 *PaymasterV06Session) AddStake(unstakeDelaySec uint32) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.AddStake(&_PaymasterV06.TransactOpts, unstakeDelaySec)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x0396cb60.
+//
+// Solidity: function addStake(uint32 unstakeDelaySec) payable returns()
+func (_PaymasterV06 *PaymasterV06TransactorSession) AddStake(unstakeDelaySec uint32) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.AddStake(&_PaymasterV06.TransactOpts, unstakeDelaySec)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xd0e30db0.
+//
+// Solidity: function deposit() payable returns()
+func (_PaymasterV06 *PaymasterV06Transactor) Deposit(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PaymasterV06.contract.Transact(opts, "deposit")
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xd0e30db0.
+//
+// Solidity: function deposit() payable returns()
+func (_PaymasterV06 *PaymasterV06Session) Deposit() (*types.Transaction, error) {
+	return _PaymasterV06.Contract.Deposit(&_PaymasterV06.TransactOpts)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xd0e30db0.
+//
+// Solidity: function deposit() payable returns()
+func (_PaymasterV06 *PaymasterV06TransactorSession) Deposit() (*types.Transaction, error) {
+	return _PaymasterV06.Contract.Deposit(&_PaymasterV06.TransactOpts)
+}
+
+// PostOp is a paid mutator transaction binding the contract method 0xa9a23409.
+//
+// Solidity: function postOp(uint8 mode, bytes context, uint256 actualGasCost) returns()
+func (_PaymasterV06 *PaymasterV06Transactor) PostOp(opts *bind.TransactOpts, mode uint8, context []byte, actualGasCost *big.Int) (*types.Transaction, error) {
+	return _PaymasterV06.contract.Transact(opts, "postOp", mode, context, actualGasCost)
+}
+
+// PostOp is a paid mutator transaction binding the contract method 0xa9a23409.
+//
+// Solidity: function postOp(uint8 mode, bytes context, uint256 actualGasCost) returns()
+func (_PaymasterV06 *PaymasterV06Session) PostOp(mode uint8, context []byte, actualGasCost *big.Int) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.PostOp(&_PaymasterV06.TransactOpts, mode, context, actualGasCost)
+}
+
+// PostOp is a paid mutator transaction binding the contract method 0xa9a23409.
+//
+// Solidity: function postOp(uint8 mode, bytes context, uint256 actualGasCost) returns()
+func (_PaymasterV06 *PaymasterV06TransactorSession) PostOp(mode uint8, context []byte, actualGasCost *big.Int) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.PostOp(&_PaymasterV06.TransactOpts, mode, context, actualGasCost)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_PaymasterV06 *PaymasterV06Transactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PaymasterV06.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_PaymasterV06 *PaymasterV06Session) RenounceOwnership() (*types.Transaction, error) {
+	return _PaymasterV06.Contract.RenounceOwnership(&_PaymasterV06.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_PaymasterV06 *PaymasterV06TransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _PaymasterV06.Contract.RenounceOwnership(&_PaymasterV06.TransactOpts)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_PaymasterV06 *PaymasterV06Transactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _PaymasterV06.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_PaymasterV06 *PaymasterV06Session) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.TransferOwnership(&_PaymasterV06.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_PaymasterV06 *PaymasterV06TransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.TransferOwnership(&_PaymasterV06.TransactOpts, newOwner)
+}
+
+// UnlockStake is a paid mutator transaction binding the contract method 0xbb9fe6bf.
+//
+// Solidity: function unlockStake() returns()
+func (_PaymasterV06 *PaymasterV06Transactor) UnlockStake(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PaymasterV06.contract.Transact(opts, "unlockStake")
+}
+
+// UnlockStake is a paid mutator transaction binding the contract method 0xbb9fe6bf.
+//
+// Solidity: function unlockStake() returns()
+func (_PaymasterV06 *PaymasterV06Session) UnlockStake() (*types.Transaction, error) {
+	return _PaymasterV06.Contract.UnlockStake(&_PaymasterV06.TransactOpts)
+}
+
+// UnlockStake is a paid mutator transaction binding the contract method 0xbb9fe6bf.
+//
+// Solidity: function unlockStake() returns()
+func (_PaymasterV06 *PaymasterV06TransactorSession) UnlockStake() (*types.Transaction, error) {
+	return _PaymasterV06.Contract.UnlockStake(&_PaymasterV06.TransactOpts)
+}
+
+// ValidatePaymasterUserOp is a paid mutator transaction binding the contract method 0xf465c77e.
+//
+// Solidity: function validatePaymasterUserOp((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp, bytes32 userOpHash, uint256 maxCost) returns(bytes context, uint256 validationData)
+func (_PaymasterV06 *PaymasterV06Transactor) ValidatePaymasterUserOp(opts *bind.TransactOpts, userOp UserOperation, userOpHash [32]byte, maxCost *big.Int) (*types.Transaction, error) {
+	return _PaymasterV06.contract.Transact(opts, "validatePaymasterUserOp", userOp, userOpHash, maxCost)
+}
+
+// ValidatePaymasterUserOp is a paid mutator transaction binding the contract method 0xf465c77e.
+//
+// Solidity: function validatePaymasterUserOp((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp, bytes32 userOpHash, uint256 maxCost) returns(bytes context, uint256 validationData)
+func (_PaymasterV06 *PaymasterV06Session) ValidatePaymasterUserOp(userOp UserOperation, userOpHash [32]byte, maxCost *big.Int) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.ValidatePaymasterUserOp(&_PaymasterV06.TransactOpts, userOp, userOpHash, maxCost)
+}
+
+// ValidatePaymasterUserOp is a paid mutator transaction binding the contract method 0xf465c77e.
+//
+// Solidity: function validatePaymasterUserOp((address,uint256,bytes,bytes,uint256,uint256,uint256,uint256,uint256,bytes,bytes) userOp, bytes32 userOpHash, uint256 maxCost) returns(bytes context, uint256 validationData)
+func (_PaymasterV06 *PaymasterV06TransactorSession) ValidatePaymasterUserOp(userOp UserOperation, userOpHash [32]byte, maxCost *big.Int) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.ValidatePaymasterUserOp(&_PaymasterV06.TransactOpts, userOp, userOpHash, maxCost)
+}
+
+// WithdrawStake is a paid mutator transaction binding the contract method 0xc23a5cea.
+//
+// Solidity: function withdrawStake(address withdrawAddress) returns()
+func (_PaymasterV06 *PaymasterV06Transactor) WithdrawStake(opts *bind.TransactOpts, withdrawAddress common.Address) (*types.Transaction, error) {
+	return _PaymasterV06.contract.Transact(opts, "withdrawStake", withdrawAddress)
+}
+
+// WithdrawStake is a paid mutator transaction binding the contract method 0xc23a5cea.
+//
+// Solidity: function withdrawStake(address withdrawAddress) returns()
+func (_PaymasterV06 *PaymasterV06Session) WithdrawStake(withdrawAddress common.Address) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.WithdrawStake(&_PaymasterV06.TransactOpts, withdrawAddress)
+}
+
+// WithdrawStake is a paid mutator transaction binding the contract method 0xc23a5cea.
+//
+// Solidity: function withdrawStake(address withdrawAddress) returns()
+func (_PaymasterV06 *PaymasterV06TransactorSession) WithdrawStake(withdrawAddress common.Address) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.WithdrawStake(&_PaymasterV06.TransactOpts, withdrawAddress)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0x205c2878.
+//
+// Solidity: function withdrawTo(address withdrawAddress, uint256 amount) returns()
+func (_PaymasterV06 *PaymasterV06Transactor) WithdrawTo(opts *bind.TransactOpts, withdrawAddress common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _PaymasterV06.contract.Transact(opts, "withdrawTo", withdrawAddress, amount)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0x205c2878.
+//
+// Solidity: function withdrawTo(address withdrawAddress, uint256 amount) returns()
+func (_PaymasterV06 *PaymasterV06Session) WithdrawTo(withdrawAddress common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.WithdrawTo(&_PaymasterV06.TransactOpts, withdrawAddress, amount)
+}
+
+// WithdrawTo is a paid mutator transaction binding the contract method 0x205c2878.
+//
+// Solidity: function withdrawTo(address withdrawAddress, uint256 amount) returns()
+func (_PaymasterV06 *PaymasterV06TransactorSession) WithdrawTo(withdrawAddress common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _PaymasterV06.Contract.WithdrawTo(&_PaymasterV06.TransactOpts, withdrawAddress, amount)
+}
+
+// PaymasterV06OwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the PaymasterV06 contract.
+type PaymasterV06OwnershipTransferredIterator struct {
+	Event *PaymasterV06OwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymasterV06OwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymasterV06OwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymasterV06OwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymasterV06OwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymasterV06OwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymasterV06OwnershipTransferred represents a OwnershipTransferred event raised by the PaymasterV06 contract.
+type PaymasterV06OwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_PaymasterV06 *PaymasterV06Filterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*PaymasterV06OwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _PaymasterV06.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymasterV06OwnershipTransferredIterator{contract: _PaymasterV06.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_PaymasterV06 *PaymasterV06Filterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *PaymasterV06OwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _PaymasterV06.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymasterV06OwnershipTransferred)
+				if err := _PaymasterV06.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_PaymasterV06 *PaymasterV06Filterer) ParseOwnershipTransferred(log types.Log) (*PaymasterV06OwnershipTransferred, error) {
+	event := new(PaymasterV06OwnershipTransferred)
+	if err := _PaymasterV06.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/chain"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/store"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// SetupAdminAPI registers the /admin routes, authenticated with the
+// configured API key in the X-API-Key header. Chain specific routes are
+// registered like the wallet routes, unscoped for the default chain and
+// under /admin/chains/:chainId.
+func SetupAdminAPI(e *echo.Echo, adminStore store.Store, chains *chain.Registry, apiKey string) *echo.Group {
+	g := e.Group("/admin", middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		KeyLookup: "header:X-API-Key",
+		Validator: func(key string, c echo.Context) (bool, error) {
+			if apiKey == "" {
+				return false, errors.New("admin API is disabled: API_KEY is not configured")
+			}
+			return subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1, nil
+		},
+		ErrorHandler: func(err error, c echo.Context) error {
+			return handleError(c, apperr.Wrap(apperr.KindUnauthorized, "unauthorized", err))
+		},
+	}))
+
+	g.GET("/policies", func(c echo.Context) error {
+		policies, err := adminStore.GetAllPolicies()
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusOK, policies)
+	})
+	g.POST("/policies", func(c echo.Context) error {
+		var policy model.SponsorshipPolicy
+		err := c.Bind(&policy)
+		if err != nil {
+			return handleError(c, err)
+		}
+		if policy.Name == "" {
+			return handleError(c, apperr.Invalidf("policy name is required"))
+		}
+
+		now := time.Now()
+		policy.CreatedAt = now
+		policy.UpdatedAt = now
+		policy, err = adminStore.CreatePolicy(policy)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusCreated, policy)
+	})
+	g.GET("/policies/:id", func(c echo.Context) error {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return handleError(c, apperr.Invalidf("invalid policy id %q", c.Param("id")))
+		}
+		policy, err := adminStore.GetPolicy(id)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusOK, policy)
+	})
+	g.PUT("/policies/:id", func(c echo.Context) error {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return handleError(c, apperr.Invalidf("invalid policy id %q", c.Param("id")))
+		}
+		existing, err := adminStore.GetPolicy(id)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		var policy model.SponsorshipPolicy
+		err = c.Bind(&policy)
+		if err != nil {
+			return handleError(c, err)
+		}
+		policy.ID = id
+		policy.CreatedAt = existing.CreatedAt
+		policy.UpdatedAt = time.Now()
+
+		err = adminStore.UpdatePolicy(policy)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusOK, policy)
+	})
+	g.DELETE("/policies/:id", func(c echo.Context) error {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return handleError(c, apperr.Invalidf("invalid policy id %q", c.Param("id")))
+		}
+		err = adminStore.DeletePolicy(id)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	setupPaymasterAdminAPI(g.Group("", withDefaultChain(chains)))
+	setupPaymasterAdminAPI(g.Group("/chains/:chainId", withChain(chains)))
+
+	return g
+}
@@ -1,126 +1,484 @@
 package api
 
 import (
-	"fmt"
+	"encoding/json"
 	"math/big"
 	"net/http"
+	"strconv"
 	"web3-account-abstraction-api/generated/abi/account"
-	contract "web3-account-abstraction-api/internal/contracts"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/calldata"
+	"web3-account-abstraction-api/internal/chain"
 	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/revert"
 	"web3-account-abstraction-api/internal/store"
 	"web3-account-abstraction-api/internal/usecase"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/labstack/echo/v4"
 )
 
-func handleError(c echo.Context, err error) error {
-	return c.String(http.StatusBadRequest, err.Error())
+const chainContextKey = "chain"
+
+// SetupAPI registers the wallet routes twice: unscoped for the default chain
+// and under /chains/:chainId for every chain in the registry.
+func SetupAPI(e *echo.Echo, walletStore store.Store, chains *chain.Registry) error {
+	for _, g := range []*echo.Group{
+		e.Group("", withDefaultChain(chains)),
+		e.Group("/chains/:chainId", withChain(chains)),
+	} {
+		setupWalletAPI(g, walletStore)
+		setupWalletDepositAPI(g, walletStore)
+	}
+	return nil
+}
+
+func withDefaultChain(chains *chain.Registry) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ch, err := chains.Default()
+			if err != nil {
+				return handleError(c, err)
+			}
+			c.Set(chainContextKey, ch)
+			return next(c)
+		}
+	}
+}
+
+func withChain(chains *chain.Registry) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id, err := strconv.ParseInt(c.Param("chainId"), 10, 64)
+			if err != nil {
+				return handleError(c, apperr.Invalidf("invalid chain id %q", c.Param("chainId")))
+			}
+			ch, err := chains.Get(id)
+			if err != nil {
+				return handleError(c, err)
+			}
+			c.Set(chainContextKey, ch)
+			return next(c)
+		}
+	}
 }
 
-func SetupAPI(e *echo.Echo, store store.Store, u usecase.Usecase, contracts contract.Contracts) error {
+func chainOf(c echo.Context) *chain.Chain {
+	return c.Get(chainContextKey).(*chain.Chain)
+}
+
+// walletParam is the :wallet path parameter, checksummed as wallets are
+// stored.
+func walletParam(c echo.Context) (string, error) {
+	address, err := parseAddress(c.Param("wallet"))
+	if err != nil {
+		return "", err
+	}
+	return address.Hex(), nil
+}
+
+// paymasterFor is the paymaster sponsoring an operation on ch, nil when the
+// wallet pays for it itself.
+func paymasterFor(ch *chain.Chain, selfPaid bool) *common.Address {
+	if selfPaid {
+		return nil
+	}
+	return &ch.Contracts.PaymasterAddress
+}
+
+func setupWalletAPI(e *echo.Group, walletStore store.Store) {
+	e.GET("/fees", func(c echo.Context) error {
+		ch := chainOf(c)
+		fees, err := ch.Fees.SuggestAll(c.Request().Context())
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusOK, fees)
+	})
+
 	e.GET("/wallet", func(c echo.Context) error {
-		wallets, err := store.GetAllWallet()
+		ch := chainOf(c)
+		wallets, err := walletStore.GetAllWallet(ch.ID)
 		if err != nil {
 			return handleError(c, err)
 		}
 
-		return c.JSON(http.StatusOK, wallets)
+		return respond(c, http.StatusOK, wallets)
 	})
+	type CreateWalletPayload struct {
+		// register the predicted address only; the wallet is deployed by
+		// its first operation
+		Counterfactual bool `json:"counterfactual"`
+		// owner held by the client, which then signs through
+		// prepare/submit
+		Owner string `json:"owner"`
+	}
 	e.POST("/wallet", func(c echo.Context) error {
-		walletCount, err := store.CountWallet()
+		ch := chainOf(c)
+		var payload CreateWalletPayload
+		err := c.Bind(&payload)
 		if err != nil {
 			return handleError(c, err)
 		}
 
-		nextSalt := common.LeftPadBytes(common.FromHex(fmt.Sprintf("%x", walletCount+1)), 32)
-		// TODO: adjust can use paymaster or not
-		simpleOp := usecase.SimpleUserOperation{
-			WalletSalt:    nextSalt,
-			CallData:      common.FromHex("0x"),
-			Paymaster:     &contracts.PaymasterAddress,
-			PaymasterData: common.FromHex("0x"),
+		opts := usecase.CreateWalletOptions{Counterfactual: payload.Counterfactual}
+		if payload.Owner != "" {
+			if !common.IsHexAddress(payload.Owner) {
+				return handleError(c, apperr.Invalidf("invalid owner address %q", payload.Owner))
+			}
+			owner := common.HexToAddress(payload.Owner)
+			opts.Owner = &owner
 		}
 
-		_, err = u.SendUserOperation(simpleOp)
+		wallet, err := ch.Usecase.CreateWallet(opts)
 		if err != nil {
 			return handleError(c, err)
 		}
-		addr, _ := contracts.GetSenderAddres([32]byte(nextSalt))
 
-		wallet := model.UserWallet{
-			Sender: addr.String(),
-		}
+		return respond(c, http.StatusOK, wallet)
+	})
 
-		err = store.CreateWallet(wallet)
+	e.POST("/wallet/:wallet/deploy", func(c echo.Context) error {
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		wallet, err := walletStore.GetWallet(ch.ID, walletAddress)
 		if err != nil {
 			return handleError(c, err)
 		}
 
-		return c.JSON(http.StatusOK, wallet)
+		wallet, err = ch.Usecase.DeployWallet(wallet)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusOK, wallet)
 	})
 
 	type SendPayload struct {
-		CallData string `json:"callData"`
+		CallData string        `json:"callData"`
+		SelfPaid bool          `json:"selfPaid"`
+		FeeTier  model.FeeTier `json:"feeTier"`
 	}
 	e.POST("/wallet/:address/send", func(c echo.Context) error {
+		ch := chainOf(c)
 		s := SendPayload{}
 		err := c.Bind(&s)
 		if err != nil {
 			return handleError(c, err)
 		}
-		addr := c.Param("address")
-
-		sender := common.HexToAddress(addr)
+		sender, err := parseAddress(c.Param("address"))
+		if err != nil {
+			return handleError(c, err)
+		}
 
 		simpleOp := usecase.SimpleUserOperation{
 			WalletSalt:    nil,
-			CallData:      common.FromHex("0x"),
-			Paymaster:     &contracts.PaymasterAddress,
+			CallData:      common.FromHex(s.CallData),
+			Paymaster:     paymasterFor(ch, s.SelfPaid),
+			PaymasterData: common.FromHex("0x"),
+			Sender:        &sender,
+			FeeTier:       s.FeeTier,
+		}
+
+		hash, err := ch.Usecase.SendUserOperation(simpleOp)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		return userOperationSent(c, hash)
+	})
+
+	type ExecutePayload struct {
+		Target   string            `json:"target"`
+		Value    string            `json:"value"`
+		Data     string            `json:"data"`
+		Function string            `json:"function"`
+		Args     []json.RawMessage `json:"args"`
+	}
+	parseCall := func(payload ExecutePayload) (usecase.Call, error) {
+		if !common.IsHexAddress(payload.Target) {
+			return usecase.Call{}, apperr.Invalidf("invalid target address %q", payload.Target)
+		}
+		value := big.NewInt(0)
+		if payload.Value != "" {
+			_, ok := value.SetString(payload.Value, 0)
+			if !ok {
+				return usecase.Call{}, apperr.Invalidf("invalid value %q", payload.Value)
+			}
+		}
+
+		var data []byte
+		var err error
+		switch {
+		case payload.Data != "" && payload.Function != "":
+			return usecase.Call{}, apperr.Invalidf("provide either data or function, not both")
+		case payload.Function != "":
+			data, err = calldata.PackFunctionCall(payload.Function, payload.Args)
+			if err != nil {
+				return usecase.Call{}, apperr.Invalid(err)
+			}
+		case payload.Data != "":
+			data, err = hexutil.Decode(payload.Data)
+			if err != nil {
+				return usecase.Call{}, apperr.Invalidf("invalid data: %w", err)
+			}
+		}
+
+		return usecase.Call{
+			Target: common.HexToAddress(payload.Target),
+			Value:  value,
+			Data:   data,
+		}, nil
+	}
+	e.POST("/wallet/:wallet/execute", func(c echo.Context) error {
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		var payload struct {
+			ExecutePayload
+			SelfPaid bool          `json:"selfPaid"`
+			FeeTier  model.FeeTier `json:"feeTier"`
+		}
+		err = c.Bind(&payload)
+		if err != nil {
+			return handleError(c, err)
+		}
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		call, err := parseCall(payload.ExecutePayload)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		hash, err := ch.Usecase.Execute(common.HexToAddress(walletAddress), call, usecase.ExecuteOptions{
+			SelfPaid: payload.SelfPaid,
+			FeeTier:  payload.FeeTier,
+		})
+		if err != nil {
+			return handleError(c, err)
+		}
+		return userOperationSent(c, hash)
+	})
+
+	// packCalls encodes the call of a prepared or quoted operation through
+	// execute; the deployed account has no batch call, so an operation runs
+	// exactly one
+	packCalls := func(ch *chain.Chain, payloads []ExecutePayload) ([]byte, error) {
+		if len(payloads) != 1 {
+			return nil, apperr.Invalidf("an operation runs exactly one call, got %d", len(payloads))
+		}
+		call, err := parseCall(payloads[0])
+		if err != nil {
+			return nil, err
+		}
+		return ch.Contracts.GetExecuteCallData(call.Target, call.Value, call.Data)
+	}
+
+	// client-side signing: prepare returns the operation and the hash the
+	// owner signs, submit forwards it with the signature
+	type PreparePayload struct {
+		Calls    []ExecutePayload `json:"calls"`
+		SelfPaid bool             `json:"selfPaid"`
+		FeeTier  model.FeeTier    `json:"feeTier"`
+	}
+	e.POST("/wallet/:wallet/prepare", func(c echo.Context) error {
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		var payload PreparePayload
+		err = c.Bind(&payload)
+		if err != nil {
+			return handleError(c, err)
+		}
+		if len(payload.Calls) == 0 {
+			return handleError(c, apperr.Invalidf("no calls to prepare"))
+		}
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		callData, err := packCalls(ch, payload.Calls)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		sender := common.HexToAddress(walletAddress)
+		prepared, err := ch.Usecase.PrepareUserOperation(usecase.SimpleUserOperation{
+			Sender:        &sender,
+			CallData:      callData,
+			Paymaster:     paymasterFor(ch, payload.SelfPaid),
 			PaymasterData: common.FromHex("0x"),
+			FeeTier:       payload.FeeTier,
+		})
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusOK, prepared)
+	})
+
+	// quote prices the calls in the paymaster token without sending them;
+	// data is null when the paymaster does not charge in a token
+	type QuotePayload struct {
+		Calls   []ExecutePayload `json:"calls"`
+		FeeTier model.FeeTier    `json:"feeTier"`
+	}
+	e.POST("/wallet/:wallet/quote", func(c echo.Context) error {
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		var payload QuotePayload
+		err = c.Bind(&payload)
+		if err != nil {
+			return handleError(c, err)
+		}
+		if len(payload.Calls) == 0 {
+			return handleError(c, apperr.Invalidf("no calls to quote"))
+		}
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		callData, err := packCalls(ch, payload.Calls)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		sender := common.HexToAddress(walletAddress)
+		quote, err := ch.Usecase.QuoteUserOperation(usecase.SimpleUserOperation{
 			Sender:        &sender,
+			CallData:      callData,
+			Paymaster:     &ch.Contracts.PaymasterAddress,
+			PaymasterData: common.FromHex("0x"),
+			FeeTier:       payload.FeeTier,
+		})
+		if err != nil {
+			return handleError(c, err)
 		}
+		return respond(c, http.StatusOK, quote)
+	})
 
-		hash, err := u.SendUserOperation(simpleOp)
+	type SubmitPayload struct {
+		UserOperation model.UserOperation `json:"userOperation"`
+		Signature     hexutil.Bytes       `json:"signature"`
+	}
+	e.POST("/wallet/:wallet/submit", func(c echo.Context) error {
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		var payload SubmitPayload
+		err = c.Bind(&payload)
+		if err != nil {
+			return handleError(c, err)
+		}
+		if payload.UserOperation.Sender != common.HexToAddress(walletAddress) {
+			return handleError(c, apperr.Invalidf("operation sender %s is not wallet %s", payload.UserOperation.Sender, walletAddress))
+		}
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
 		if err != nil {
 			return handleError(c, err)
 		}
 
-		return c.String(http.StatusOK, hash)
+		hash, err := ch.Usecase.SubmitUserOperation(payload.UserOperation, payload.Signature)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return userOperationSent(c, hash)
 	})
 	e.GET("/wallet/tx/:hash/status", func(c echo.Context) error {
+		ch := chainOf(c)
+		hash := c.Param("hash")
+		status, err := ch.Usecase.GetUserOperationStatus(hash)
+		if err != nil {
+			return handleError(c, err)
+		}
+		var revertReason *revert.Error
+		if status.Reason != nil {
+			revertReason = revert.FromReason(*status.Reason)
+		}
+		return respond(c, http.StatusOK, struct {
+			model.UserOperationRecord
+			RevertReason *revert.Error `json:"revertReason,omitempty"`
+		}{status, revertReason})
+	})
+	e.GET("/wallet/tx/:hash/receipt", func(c echo.Context) error {
+		ch := chainOf(c)
+		hash := c.Param("hash")
+		receipt, err := ch.Usecase.GetUserOperationReceipt(hash)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusOK, receipt)
+	})
+	e.GET("/wallet/tx/:hash/calls", func(c echo.Context) error {
+		ch := chainOf(c)
 		hash := c.Param("hash")
-		status, err := u.GetUserOperationStatus(hash)
+		calls, err := ch.Usecase.GetUserOperationCalls(hash)
 		if err != nil {
 			return handleError(c, err)
 		}
-		return c.String(http.StatusOK, fmt.Sprintf("%v", status))
+		return respond(c, http.StatusOK, calls)
+	})
+	e.GET("/wallet/tx/:hash", func(c echo.Context) error {
+		ch := chainOf(c)
+		hash := c.Param("hash")
+		userOp, err := ch.Usecase.GetUserOperationByHash(hash)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusOK, userOp)
 	})
 	e.GET("/wallet/:wallet/eth/balance", func(c echo.Context) error {
-		walletAddress := c.Param("wallet")
-		_, err := store.GetWallet(walletAddress)
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
 		if err != nil {
 			return handleError(c, err)
 		}
-		balance, err := contracts.GetETHBalance(common.HexToAddress(walletAddress))
+		balance, err := ch.Contracts.GetETHBalance(common.HexToAddress(walletAddress))
 		if err != nil {
 			return handleError(c, err)
 		}
-		return c.String(http.StatusOK, balance.String())
+		return respond(c, http.StatusOK, map[string]string{"balance": balance.String()})
 	})
 	e.GET("/wallet/:wallet/:tokenAddress/balance", func(c echo.Context) error {
-		walletAddress := c.Param("wallet")
-		tokenAddress := c.Param("tokenAddress")
-		_, err := store.GetWallet(walletAddress)
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		tokenAddress, err := parseAddress(c.Param("tokenAddress"))
+		if err != nil {
+			return handleError(c, err)
+		}
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
 		if err != nil {
 			return handleError(c, err)
 		}
 
-		balance, err := contracts.GetERC20Balance(common.HexToAddress(walletAddress), common.HexToAddress(tokenAddress))
+		balance, err := ch.Contracts.GetERC20Balance(common.HexToAddress(walletAddress), tokenAddress)
 		if err != nil {
-			handleError(c, err)
+			return handleError(c, err)
 		}
-		return c.String(http.StatusOK, balance.String())
+		return respond(c, http.StatusOK, map[string]string{"balance": balance.String()})
 	})
 
 	type TransferPayload struct {
@@ -128,13 +486,17 @@ func SetupAPI(e *echo.Echo, store store.Store, u usecase.Usecase, contracts cont
 		To     string `json:"to"`
 	}
 	e.POST("/wallet/:wallet/eth/transfer", func(c echo.Context) error {
-		walletAddress := c.Param("wallet")
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
 		var payload TransferPayload
-		err := c.Bind(&payload)
+		err = c.Bind(&payload)
 		if err != nil {
 			return handleError(c, err)
 		}
-		_, err = store.GetWallet(walletAddress)
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
 		if err != nil {
 			return handleError(c, err)
 		}
@@ -145,29 +507,37 @@ func SetupAPI(e *echo.Echo, store store.Store, u usecase.Usecase, contracts cont
 			return handleError(c, err)
 		}
 
+		sender := common.HexToAddress(walletAddress)
 		simpleOp := usecase.SimpleUserOperation{
-			Sender:        (*common.Address)(common.FromHex(walletAddress)),
+			Sender:        &sender,
 			CallData:      callData,
-			Paymaster:     &contracts.PaymasterAddress,
+			Paymaster:     &ch.Contracts.PaymasterAddress,
 			PaymasterData: common.FromHex("0x"),
 		}
 
-		hash, err := u.SendUserOperation(simpleOp)
+		hash, err := ch.Usecase.SendUserOperation(simpleOp)
 		if err != nil {
 			return handleError(c, err)
 		}
-		return c.String(http.StatusOK, hash)
+		return userOperationSent(c, hash)
 	})
 
 	e.POST("/wallet/:wallet/:tokenAddress/transfer", func(c echo.Context) error {
-		walletAddress := c.Param("wallet")
-		tokenAddress := c.Param("tokenAddress")
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		tokenAddress, err := parseAddress(c.Param("tokenAddress"))
+		if err != nil {
+			return handleError(c, err)
+		}
 		var payload TransferPayload
-		err := c.Bind(&payload)
+		err = c.Bind(&payload)
 		if err != nil {
 			return handleError(c, err)
 		}
-		_, err = store.GetWallet(walletAddress)
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
 		if err != nil {
 			return handleError(c, err)
 		}
@@ -176,24 +546,23 @@ func SetupAPI(e *echo.Echo, store store.Store, u usecase.Usecase, contracts cont
 		if err != nil {
 			return handleError(c, err)
 		}
-		callData, err := abi.Pack("withdrawERC20", common.HexToAddress(tokenAddress), common.HexToAddress(payload.To), big.NewInt(int64(payload.Amount)))
+		callData, err := abi.Pack("withdrawERC20", tokenAddress, common.HexToAddress(payload.To), big.NewInt(int64(payload.Amount)))
 		if err != nil {
 			return handleError(c, err)
 		}
 
+		sender := common.HexToAddress(walletAddress)
 		simpleOp := usecase.SimpleUserOperation{
-			Sender:        (*common.Address)(common.FromHex(walletAddress)),
+			Sender:        &sender,
 			CallData:      callData,
-			Paymaster:     &contracts.PaymasterAddress,
+			Paymaster:     &ch.Contracts.PaymasterAddress,
 			PaymasterData: common.FromHex("0x"),
 		}
 
-		hash, err := u.SendUserOperation(simpleOp)
+		hash, err := ch.Usecase.SendUserOperation(simpleOp)
 		if err != nil {
 			return handleError(c, err)
 		}
-		return c.String(http.StatusOK, hash)
+		return userOperationSent(c, hash)
 	})
-
-	return nil
 }
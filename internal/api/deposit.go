@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+	"web3-account-abstraction-api/internal/store"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+)
+
+// setupWalletDepositAPI registers the routes managing a wallet's EntryPoint
+// deposit, which pays for its self-paid operations.
+func setupWalletDepositAPI(e *echo.Group, walletStore store.Store) {
+	e.GET("/wallet/:wallet/deposit", func(c echo.Context) error {
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		deposit, err := ch.Usecase.GetWalletDeposit(common.HexToAddress(walletAddress))
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusOK, deposit)
+	})
+
+	type FundPayload struct {
+		Amount string `json:"amount"`
+	}
+	e.POST("/wallet/:wallet/deposit", func(c echo.Context) error {
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		var payload FundPayload
+		err = c.Bind(&payload)
+		if err != nil {
+			return handleError(c, err)
+		}
+		amount, err := parseAmount(payload.Amount)
+		if err != nil {
+			return handleError(c, err)
+		}
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		txHash, err := ch.Usecase.FundWalletDeposit(common.HexToAddress(walletAddress), amount)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return transactionHashSent(c, txHash)
+	})
+
+	type WithdrawDepositPayload struct {
+		To     string `json:"to"`
+		Amount string `json:"amount"`
+	}
+	e.POST("/wallet/:wallet/deposit/withdraw", func(c echo.Context) error {
+		ch := chainOf(c)
+		walletAddress, err := walletParam(c)
+		if err != nil {
+			return handleError(c, err)
+		}
+		var payload WithdrawDepositPayload
+		err = c.Bind(&payload)
+		if err != nil {
+			return handleError(c, err)
+		}
+		to, err := parseAddress(payload.To)
+		if err != nil {
+			return handleError(c, err)
+		}
+		amount, err := parseAmount(payload.Amount)
+		if err != nil {
+			return handleError(c, err)
+		}
+		_, err = walletStore.GetWallet(ch.ID, walletAddress)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		userOpHash, err := ch.Usecase.WithdrawWalletDeposit(common.HexToAddress(walletAddress), to, amount)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return userOperationSent(c, userOpHash)
+	})
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"math/big"
+	"net/http"
+	"web3-account-abstraction-api/internal/apperr"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/labstack/echo/v4"
+)
+
+// setupPaymasterAdminAPI registers the routes that view and manage the
+// paymaster's EntryPoint deposit and stake. Transactions are only sent, the
+// response carries their hash.
+func setupPaymasterAdminAPI(g *echo.Group) {
+	g.GET("/paymaster/deposit", func(c echo.Context) error {
+		ch := chainOf(c)
+		info, err := ch.Contracts.GetPaymasterDepositInfo()
+		if err != nil {
+			return handleError(c, err)
+		}
+		return respond(c, http.StatusOK, info)
+	})
+
+	type AmountPayload struct {
+		Amount string `json:"amount"`
+	}
+	g.POST("/paymaster/deposit", func(c echo.Context) error {
+		ch := chainOf(c)
+		var payload AmountPayload
+		err := c.Bind(&payload)
+		if err != nil {
+			return handleError(c, err)
+		}
+		amount, err := parseAmount(payload.Amount)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		tx, err := ch.Contracts.DepositPaymaster(amount)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return transactionSent(c, tx)
+	})
+
+	type WithdrawPayload struct {
+		To     string `json:"to"`
+		Amount string `json:"amount"`
+	}
+	g.POST("/paymaster/withdraw", func(c echo.Context) error {
+		ch := chainOf(c)
+		var payload WithdrawPayload
+		err := c.Bind(&payload)
+		if err != nil {
+			return handleError(c, err)
+		}
+		to, err := parseAddress(payload.To)
+		if err != nil {
+			return handleError(c, err)
+		}
+		amount, err := parseAmount(payload.Amount)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		tx, err := ch.Contracts.WithdrawPaymasterDeposit(to, amount)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return transactionSent(c, tx)
+	})
+
+	type StakePayload struct {
+		Amount          string `json:"amount"`
+		UnstakeDelaySec uint32 `json:"unstakeDelaySec"`
+	}
+	g.POST("/paymaster/stake", func(c echo.Context) error {
+		ch := chainOf(c)
+		var payload StakePayload
+		err := c.Bind(&payload)
+		if err != nil {
+			return handleError(c, err)
+		}
+		amount, err := parseAmount(payload.Amount)
+		if err != nil {
+			return handleError(c, err)
+		}
+		if payload.UnstakeDelaySec == 0 {
+			return handleError(c, apperr.Invalidf("unstakeDelaySec is required"))
+		}
+
+		tx, err := ch.Contracts.AddPaymasterStake(payload.UnstakeDelaySec, amount)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return transactionSent(c, tx)
+	})
+	g.POST("/paymaster/unlock-stake", func(c echo.Context) error {
+		ch := chainOf(c)
+		tx, err := ch.Contracts.UnlockPaymasterStake()
+		if err != nil {
+			return handleError(c, err)
+		}
+		return transactionSent(c, tx)
+	})
+
+	type WithdrawStakePayload struct {
+		To string `json:"to"`
+	}
+	g.POST("/paymaster/withdraw-stake", func(c echo.Context) error {
+		ch := chainOf(c)
+		var payload WithdrawStakePayload
+		err := c.Bind(&payload)
+		if err != nil {
+			return handleError(c, err)
+		}
+		to, err := parseAddress(payload.To)
+		if err != nil {
+			return handleError(c, err)
+		}
+
+		tx, err := ch.Contracts.WithdrawPaymasterStake(to)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return transactionSent(c, tx)
+	})
+}
+
+func transactionSent(c echo.Context, tx *types.Transaction) error {
+	return transactionHashSent(c, tx.Hash())
+}
+
+// parseAmount parses a positive wei amount, decimal or 0x prefixed hex.
+func parseAmount(s string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(s, 0)
+	if !ok || amount.Sign() <= 0 {
+		return nil, apperr.Invalidf("invalid amount %q", s)
+	}
+	return amount, nil
+}
+
+func parseAddress(s string) (common.Address, error) {
+	if !common.IsHexAddress(s) {
+		return common.Address{}, apperr.Invalidf("invalid address %q", s)
+	}
+	return common.HexToAddress(s), nil
+}
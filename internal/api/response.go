@@ -0,0 +1,101 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"web3-account-abstraction-api/internal/apperr"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// dataResponse is the envelope of every successful response.
+type dataResponse struct {
+	RequestID string      `json:"requestId"`
+	Data      interface{} `json:"data"`
+}
+
+// errorResponse is the envelope of every error response.
+type errorResponse struct {
+	RequestID string        `json:"requestId"`
+	Error     *apperr.Error `json:"error"`
+}
+
+// SetupResponses gives every request an ID, the X-Request-Id it was sent
+// with or a generated one, echoed in the response header and envelope. Errors
+// echo raises itself, e.g. for unknown routes, are answered in the error
+// envelope too.
+func SetupResponses(e *echo.Echo) {
+	e.Use(middleware.RequestID())
+	e.HTTPErrorHandler = func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+		err = handleError(c, err)
+		if err != nil {
+			c.Logger().Error(err)
+		}
+	}
+}
+
+func requestID(c echo.Context) string {
+	return c.Response().Header().Get(echo.HeaderXRequestID)
+}
+
+func respond(c echo.Context, status int, data interface{}) error {
+	return c.JSON(status, dataResponse{RequestID: requestID(c), Data: data})
+}
+
+// handleError answers err in the error envelope. Errors of the service itself
+// are only described in the log: the client gets a generic message with the
+// request ID to report.
+func handleError(c echo.Context, err error) error {
+	apiErr, status := classify(err)
+	if status >= http.StatusInternalServerError {
+		log.Printf("api (request %s): %v", requestID(c), err)
+	}
+	if apiErr.Kind == apperr.KindInternal {
+		apiErr = &apperr.Error{
+			Kind:    apperr.KindInternal,
+			Code:    apiErr.Code,
+			Message: fmt.Sprintf("internal error, see request %s", requestID(c)),
+		}
+	}
+	return c.JSON(status, errorResponse{RequestID: requestID(c), Error: apiErr})
+}
+
+// classify maps the errors echo raises, e.g. when a payload does not bind,
+// by their status code and every other error through apperr.From.
+func classify(err error) (*apperr.Error, int) {
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) {
+		apiErr := apperr.From(err)
+		return apiErr, apiErr.Status()
+	}
+
+	kind := apperr.KindInternal
+	switch {
+	case httpErr.Code == http.StatusNotFound:
+		kind = apperr.KindNotFound
+	case httpErr.Code == http.StatusUnauthorized:
+		kind = apperr.KindUnauthorized
+	case httpErr.Code < http.StatusInternalServerError:
+		kind = apperr.KindValidation
+	}
+	return &apperr.Error{
+		Kind:    kind,
+		Code:    string(kind),
+		Message: fmt.Sprint(httpErr.Message),
+	}, httpErr.Code
+}
+
+func userOperationSent(c echo.Context, userOpHash string) error {
+	return respond(c, http.StatusAccepted, map[string]string{"userOpHash": userOpHash})
+}
+
+func transactionHashSent(c echo.Context, txHash common.Hash) error {
+	return respond(c, http.StatusAccepted, map[string]common.Hash{"txHash": txHash})
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"web3-account-abstraction-api/internal/apperr"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestErrorEnvelope(t *testing.T) {
+	e := echo.New()
+	SetupResponses(e)
+	e.GET("/internal", func(c echo.Context) error {
+		return handleError(c, errors.New("dial tcp 10.0.0.5:5432: connection refused"))
+	})
+	e.GET("/invalid", func(c echo.Context) error {
+		return handleError(c, apperr.Invalidf("amount must be positive"))
+	})
+
+	tests := []struct {
+		path    string
+		status  int
+		kind    apperr.Kind
+		message string
+	}{
+		{"/internal", http.StatusInternalServerError, apperr.KindInternal, "internal error, see request test-id"},
+		{"/invalid", http.StatusBadRequest, apperr.KindValidation, "amount must be positive"},
+		{"/unknown", http.StatusNotFound, apperr.KindNotFound, "Not Found"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set(echo.HeaderXRequestID, "test-id")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: status %d, want %d", tt.path, rec.Code, tt.status)
+		}
+		var body errorResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &body)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if body.RequestID != "test-id" || body.Error == nil || body.Error.Kind != tt.kind || body.Error.Message != tt.message {
+			t.Errorf("%s: got %s", tt.path, rec.Body)
+		}
+		if strings.Contains(rec.Body.String(), "10.0.0.5") {
+			t.Errorf("%s: internal detail leaked: %s", tt.path, rec.Body)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"web3-account-abstraction-api/internal/revert"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Kind classifies an error by who is at fault, which decides the status code
+// the API answers it with.
+type Kind string
+
+const (
+	KindNotFound     Kind = "not_found"
+	KindValidation   Kind = "validation"
+	KindUnauthorized Kind = "unauthorized"
+	KindPolicyDenied Kind = "policy_denied"
+	// the bundler failed or rejected the operation
+	KindBundler Kind = "bundler"
+	// the chain's node failed or a call to it reverted
+	KindChain    Kind = "chain"
+	KindInternal Kind = "internal"
+)
+
+func (k Kind) Status() int {
+	switch k {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindPolicyDenied:
+		return http.StatusForbidden
+	case KindBundler, KindChain:
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+// Classified is implemented by errors of other packages that know their kind,
+// e.g. policy.RejectionError.
+type Classified interface {
+	error
+	ErrorKind() Kind
+}
+
+// Error is an error with its kind and a stable code clients can match on.
+type Error struct {
+	Kind    Kind   `json:"kind"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// the decoded revert or bundler rejection, if any
+	Details interface{} `json:"details,omitempty"`
+
+	err error
+}
+
+// New returns a sentinel error; wrapping it with fmt.Errorf keeps its kind.
+func New(kind Kind, code string, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Wrap classifies err, nil stays nil.
+func Wrap(kind Kind, code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Code: code, Message: err.Error(), err: err}
+}
+
+// Invalid classifies err as a problem with the request.
+func Invalid(err error) error {
+	return Wrap(KindValidation, "invalid_request", err)
+}
+
+func Invalidf(format string, args ...interface{}) error {
+	return Invalid(fmt.Errorf(format, args...))
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) ErrorKind() Kind {
+	return e.Kind
+}
+
+// Status is the status code of e's kind, except that an operation the
+// bundler or chain reverted or rejected is answered 422: the upstream worked,
+// the operation is what failed.
+func (e *Error) Status() int {
+	if _, ok := e.Details.(*revert.Error); ok {
+		return http.StatusUnprocessableEntity
+	}
+	return e.Kind.Status()
+}
+
+// From classifies err by the first *Error or Classified error it wraps. An
+// unclassified JSON-RPC or network error is the chain's, anything else
+// internal. The revert or bundler rejection an upstream error carries
+// becomes its details and code. The message is always err's own, so the
+// context it was wrapped with is kept.
+func From(err error) *Error {
+	result := &Error{Kind: KindInternal, Code: string(KindInternal), Message: err.Error(), err: err}
+
+	var typed *Error
+	var classified Classified
+	switch {
+	case errors.As(err, &typed):
+		result.Kind, result.Code, result.Details = typed.Kind, typed.Code, typed.Details
+	case errors.As(err, &classified):
+		result.Kind, result.Code = classified.ErrorKind(), string(classified.ErrorKind())
+	case isUpstream(err):
+		result.Kind, result.Code = KindChain, "chain_error"
+	}
+
+	switch result.Kind {
+	case KindBundler, KindChain, KindInternal:
+		if decoded := revert.FromError(err); decoded != nil {
+			if result.Kind == KindInternal {
+				result.Kind = KindChain
+			}
+			result.Code, result.Details = decoded.Code, decoded
+		}
+	}
+	return result
+}
+
+func isUpstream(err error) bool {
+	var rpcErr rpc.Error
+	var httpErr rpc.HTTPError
+	var netErr net.Error
+	return errors.As(err, &rpcErr) || errors.As(err, &httpErr) || errors.As(err, &netErr)
+}
@@ -1,145 +1,122 @@
 package bundler
 
 import (
-	"context"
 	"fmt"
 	"math/big"
-	"net/url"
 	"web3-account-abstraction-api/internal/model"
-	"web3-account-abstraction-api/utils"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
-var (
-	epv07DummySignature = common.FromHex("0xfffffffffffffffffffffffffffffff0000000000000000000000000000000007aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1c")
+const (
+	ProviderRundler = "rundler"
+	ProviderPimlico = "pimlico"
+	ProviderGeneric = "generic"
 )
 
-type Bundler struct {
-	version string
-
-	RPCUrl    *url.URL
-	client    *ethclient.Client
-	epAddress common.Address
-
-	dummySignature []byte
+// Bundler is the set of ERC-4337 bundler RPC calls the service depends on.
+// Provider specific behaviour (e.g. how the priority fee is suggested) lives
+// in the adapters returned by New.
+type Bundler interface {
+	SendUserOperation(userOp model.UserOperation) (SendUserOperationResult, error)
+	EstimateUserOpGas(userOp model.UserOperation) (EstimateUserOpResult, error)
+	GetUserOperationReceipt(opHash string) (*UserOperationReceipt, error)
+	GetUserOperationByHash(opHash string) (*UserOperationByHashResult, error)
+	SupportedEntryPoints() ([]common.Address, error)
+	GetMaxPriorityFeePerGas() (*big.Int, error)
 }
 
 type EstimateUserOpResult struct {
-	PreVerificationGas            *big.Int `json:"preVerificationGas"`
-	CallGasLimit                  *big.Int `json:"callGasLimit"`
-	VerificationGasLimit          *big.Int `json:"verificationGasLimit"`
+	PreVerificationGas   *big.Int `json:"preVerificationGas"`
+	CallGasLimit         *big.Int `json:"callGasLimit"`
+	VerificationGasLimit *big.Int `json:"verificationGasLimit"`
+	// nil when the bundler did not estimate them, as v0.6 bundlers don't
 	PaymasterVerificationGasLimit *big.Int `json:"paymasterVerificationGasLimit"`
+	PaymasterPostOpGasLimit       *big.Int `json:"paymasterPostOpGasLimit"`
 }
 
 type SendUserOperationResult struct {
 	TxHash string
 }
 
-func NewV07Bundler(
-	client *ethclient.Client,
-	epAddress common.Address,
-) Bundler {
-	return Bundler{
-		version:        "v0.7",
-		client:         client,
-		epAddress:      epAddress,
-		dummySignature: epv07DummySignature,
-	}
-}
-
-func (b *Bundler) SendUserOperation(userOp model.UserOperation) (SendUserOperationResult, error) {
-	requestBody := map[string]interface{}{
-		"sender":                        userOp.Sender.Hex(),
-		"nonce":                         fmt.Sprintf("0x%s", userOp.Nonce.Text(16)),
-		"callData":                      fmt.Sprintf("0x%x", userOp.CallData),
-		"callGasLimit":                  fmt.Sprintf("0x%x", userOp.CallGasLimit),
-		"verificationGasLimit":          fmt.Sprintf("0x%x", userOp.VerificationGasLimit),
-		"preVerificationGas":            fmt.Sprintf("0x%x", userOp.PreVerificationGas),
-		"maxFeePerGas":                  fmt.Sprintf("0x%x", userOp.MaxFeePerGas),
-		"maxPriorityFeePerGas":          fmt.Sprintf("0x%x", userOp.MaxPriorityFeePerGas),
-		"paymasterVerificationGasLimit": fmt.Sprintf("0x%x", userOp.PaymasterVerificationGasLimit),
-		"paymasterPostOpGasLimit":       fmt.Sprintf("0x%x", userOp.PaymasterPostOpGasLimit),
-		"signature":                     fmt.Sprintf("0x%x", userOp.Signature),
-		"paymaster":                     userOp.Paymaster.Hex(),
-		"paymasterData":                 fmt.Sprintf("0x%x", userOp.PaymasterData),
-	}
-
-	if userOp.Factory != nil && !utils.IsZeroAddress(userOp.Factory) {
-		requestBody["factory"] = userOp.Factory.Hex()
-		requestBody["factoryData"] = fmt.Sprintf("0x%x", userOp.FactoryData)
-	}
-	var txHash string
-	err := b.client.
-		Client().
-		Call(&txHash, "eth_sendUserOperation", requestBody, b.epAddress.Hex())
-
-	return SendUserOperationResult{
-		TxHash: txHash,
-	}, err
-}
-
+// UserOperationReceipt is the eth_getUserOperationReceipt result.
 type UserOperationReceipt struct {
-	Success bool    `json:"success"`
-	Reason  *string `json:"reason"`
+	UserOpHash    common.Hash        `json:"userOpHash"`
+	EntryPoint    common.Address     `json:"entryPoint"`
+	Sender        common.Address     `json:"sender"`
+	Nonce         *hexutil.Big       `json:"nonce"`
+	Paymaster     *common.Address    `json:"paymaster"`
+	ActualGasCost *hexutil.Big       `json:"actualGasCost"`
+	ActualGasUsed *hexutil.Big       `json:"actualGasUsed"`
+	Success       bool               `json:"success"`
+	Reason        *string            `json:"reason"`
+	Logs          []types.Log        `json:"logs"`
+	Receipt       TransactionReceipt `json:"receipt"`
 }
 
-func (b *Bundler) GetUserOperationReceipt(opHash string) (UserOperationReceipt, error) {
-	var result UserOperationReceipt
-	err := b.client.
-		Client().
-		Call(&result, "eth_getUserOperationReceipt", opHash)
-
-	return result, err
+// TransactionReceipt is the receipt of the bundle transaction that included
+// the user operation. Bundlers differ in which optional fields they fill, so
+// it is decoded leniently instead of through types.Receipt.
+type TransactionReceipt struct {
+	TransactionHash   common.Hash     `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint64  `json:"transactionIndex"`
+	BlockHash         common.Hash     `json:"blockHash"`
+	BlockNumber       *hexutil.Big    `json:"blockNumber"`
+	From              common.Address  `json:"from"`
+	To                *common.Address `json:"to"`
+	CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice"`
+	Status            hexutil.Uint64  `json:"status"`
+	Logs              []types.Log     `json:"logs"`
 }
 
-type EstimateRequest struct {
-	Sender      string  `json:"sender"`
-	Nonce       string  `json:"nonce"`
-	CallData    string  `json:"callData"`
-	Signature   string  `json:"signature"`
-	Factory     *string `json:"factory,omitempty"`
-	FactoryData *string `json:"factoryData,omitempty"`
+// RPCUserOperation is a user operation as returned by the bundler RPC. v0.7
+// bundlers fill the unpacked factory/paymaster fields, v0.6 bundlers initCode
+// and paymasterAndData.
+type RPCUserOperation struct {
+	Sender                        common.Address  `json:"sender"`
+	Nonce                         *hexutil.Big    `json:"nonce"`
+	InitCode                      hexutil.Bytes   `json:"initCode,omitempty"`
+	PaymasterAndData              hexutil.Bytes   `json:"paymasterAndData,omitempty"`
+	Factory                       *common.Address `json:"factory,omitempty"`
+	FactoryData                   hexutil.Bytes   `json:"factoryData,omitempty"`
+	CallData                      hexutil.Bytes   `json:"callData"`
+	CallGasLimit                  *hexutil.Big    `json:"callGasLimit"`
+	VerificationGasLimit          *hexutil.Big    `json:"verificationGasLimit"`
+	PreVerificationGas            *hexutil.Big    `json:"preVerificationGas"`
+	MaxFeePerGas                  *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas          *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Paymaster                     *common.Address `json:"paymaster,omitempty"`
+	PaymasterVerificationGasLimit *hexutil.Big    `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       *hexutil.Big    `json:"paymasterPostOpGasLimit,omitempty"`
+	PaymasterData                 hexutil.Bytes   `json:"paymasterData,omitempty"`
+	Signature                     hexutil.Bytes   `json:"signature"`
 }
 
-func newString(s string) *string {
-	return &s
+type UserOperationByHashResult struct {
+	UserOperation   RPCUserOperation `json:"userOperation"`
+	EntryPoint      common.Address   `json:"entryPoint"`
+	BlockNumber     *hexutil.Big     `json:"blockNumber"`
+	BlockHash       *common.Hash     `json:"blockHash"`
+	TransactionHash *common.Hash     `json:"transactionHash"`
 }
 
-func (b *Bundler) EstimateUserOpGas(userOp model.UserOperation) (EstimateUserOpResult, error) {
-	requestBody := EstimateRequest{
-		Sender:    userOp.Sender.Hex(),
-		Nonce:     fmt.Sprintf("0x%s", userOp.Nonce.Text(16)),
-		CallData:  fmt.Sprintf("0x%x", userOp.CallData),
-		Signature: fmt.Sprintf("0x%x", b.dummySignature),
-	}
-	b.client.SyncProgress(context.Background())
-
-	if userOp.Factory != nil && !utils.IsZeroAddress(userOp.Factory) {
-		requestBody.Factory = newString(userOp.Factory.Hex())
-		requestBody.FactoryData = newString(fmt.Sprintf("0x%x", userOp.FactoryData))
+// New returns the bundler adapter for the given provider and entry point
+// version. An empty provider falls back to rundler, which is what the service
+// was originally built on.
+func New(provider string, version model.EntryPointVersion, client *rpc.Client, epAddress common.Address) (Bundler, error) {
+	switch provider {
+	case ProviderRundler, "":
+		return NewRundlerBundler(version, client, epAddress), nil
+	case ProviderPimlico:
+		return NewPimlicoBundler(version, client, epAddress), nil
+	case ProviderGeneric:
+		return newSpecBundler(version, client, epAddress), nil
+	default:
+		return nil, fmt.Errorf("unknown bundler provider %q", provider)
 	}
-
-	result := map[string]string{}
-	err := b.client.
-		Client().
-		CallContext(context.Background(),
-			&result,
-			"eth_estimateUserOperationGas", requestBody, b.epAddress.Hex())
-
-	return EstimateUserOpResult{
-		PreVerificationGas:            big.NewInt(0).SetBytes(common.FromHex(result["preVerificationGas"])),
-		CallGasLimit:                  big.NewInt(0).SetBytes(common.FromHex(result["callGasLimit"])),
-		VerificationGasLimit:          big.NewInt(0).SetBytes(common.FromHex(result["verificationGasLimit"])),
-		PaymasterVerificationGasLimit: nil,
-	}, err
-}
-
-func (b *Bundler) GetMaxPriorityFeePerGas() (*big.Int, error) {
-	var result string
-	err := b.client.
-		Client().
-		Call(&result, "rundler_maxPriorityFeePerGas")
-	return big.NewInt(0).SetBytes(common.FromHex(result)), err
 }
@@ -0,0 +1,52 @@
+package bundler
+
+import (
+	"errors"
+	"math/big"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// pimlicoBundler is the adapter for bundlers exposing
+// pimlico_getUserOperationGasPrice.
+type pimlicoBundler struct {
+	*specBundler
+}
+
+type GasPrice struct {
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas"`
+}
+
+type UserOperationGasPrice struct {
+	Slow     GasPrice `json:"slow"`
+	Standard GasPrice `json:"standard"`
+	Fast     GasPrice `json:"fast"`
+}
+
+func NewPimlicoBundler(version model.EntryPointVersion, client *rpc.Client, epAddress common.Address) *pimlicoBundler {
+	return &pimlicoBundler{
+		specBundler: newSpecBundler(version, client, epAddress),
+	}
+}
+
+func (b *pimlicoBundler) GetUserOperationGasPrice() (UserOperationGasPrice, error) {
+	var result UserOperationGasPrice
+	err := b.call(&result, "pimlico_getUserOperationGasPrice")
+	return result, err
+}
+
+func (b *pimlicoBundler) GetMaxPriorityFeePerGas() (*big.Int, error) {
+	gasPrice, err := b.GetUserOperationGasPrice()
+	if err != nil {
+		return nil, err
+	}
+	if gasPrice.Standard.MaxPriorityFeePerGas == nil {
+		return nil, errors.New("pimlico_getUserOperationGasPrice returned no standard priority fee")
+	}
+
+	return gasPrice.Standard.MaxPriorityFeePerGas.ToInt(), nil
+}
@@ -0,0 +1,26 @@
+package bundler
+
+import (
+	"math/big"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rundlerBundler is the adapter for Alchemy's rundler.
+type rundlerBundler struct {
+	*specBundler
+}
+
+func NewRundlerBundler(version model.EntryPointVersion, client *rpc.Client, epAddress common.Address) *rundlerBundler {
+	return &rundlerBundler{
+		specBundler: newSpecBundler(version, client, epAddress),
+	}
+}
+
+func (b *rundlerBundler) GetMaxPriorityFeePerGas() (*big.Int, error) {
+	var result string
+	err := b.call(&result, "rundler_maxPriorityFeePerGas")
+	return big.NewInt(0).SetBytes(common.FromHex(result)), err
+}
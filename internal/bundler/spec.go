@@ -0,0 +1,218 @@
+package bundler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// specBundler talks to any bundler implementing the ERC-4337 RPC spec.
+// Provider adapters embed it and override the non-standard calls.
+type specBundler struct {
+	version model.EntryPointVersion
+
+	client    *rpc.Client
+	epAddress common.Address
+
+	dummySignature []byte
+}
+
+func NewV07Bundler(
+	client *rpc.Client,
+	epAddress common.Address,
+) *specBundler {
+	return newSpecBundler(model.EntryPointV07, client, epAddress)
+}
+
+func NewV06Bundler(
+	client *rpc.Client,
+	epAddress common.Address,
+) *specBundler {
+	return newSpecBundler(model.EntryPointV06, client, epAddress)
+}
+
+func newSpecBundler(version model.EntryPointVersion, client *rpc.Client, epAddress common.Address) *specBundler {
+	return &specBundler{
+		version:   version,
+		client:    client,
+		epAddress: epAddress,
+		// the dummy signature only has to have the right shape, so v0.6
+		// uses the same one
+		dummySignature: model.DummySignature,
+	}
+}
+
+// call is client.Call with a failure marked as the bundler's, so the API
+// answers it as an upstream error.
+func (b *specBundler) call(result interface{}, method string, args ...interface{}) error {
+	err := b.client.CallContext(context.Background(), result, method, args...)
+	return apperr.Wrap(apperr.KindBundler, "bundler_error", err)
+}
+
+// encodeV06 is the v0.6 RPC form, with initCode and paymasterAndData packed.
+func encodeV06(userOp model.UserOperation, signature []byte) map[string]interface{} {
+	packed := userOp.PackV06()
+	return map[string]interface{}{
+		"sender":               packed.Sender.Hex(),
+		"nonce":                fmt.Sprintf("0x%s", packed.Nonce.Text(16)),
+		"initCode":             fmt.Sprintf("0x%x", packed.InitCode),
+		"callData":             fmt.Sprintf("0x%x", packed.CallData),
+		"callGasLimit":         fmt.Sprintf("0x%x", packed.CallGasLimit),
+		"verificationGasLimit": fmt.Sprintf("0x%x", packed.VerificationGasLimit),
+		"preVerificationGas":   fmt.Sprintf("0x%x", packed.PreVerificationGas),
+		"maxFeePerGas":         fmt.Sprintf("0x%x", packed.MaxFeePerGas),
+		"maxPriorityFeePerGas": fmt.Sprintf("0x%x", packed.MaxPriorityFeePerGas),
+		"paymasterAndData":     fmt.Sprintf("0x%x", packed.PaymasterAndData),
+		"signature":            fmt.Sprintf("0x%x", signature),
+	}
+}
+
+func (b *specBundler) SendUserOperation(userOp model.UserOperation) (SendUserOperationResult, error) {
+	if b.version == model.EntryPointV06 {
+		var txHash string
+		err := b.call(&txHash, "eth_sendUserOperation", encodeV06(userOp, userOp.Signature), b.epAddress.Hex())
+		return SendUserOperationResult{
+			TxHash: txHash,
+		}, err
+	}
+
+	requestBody := map[string]interface{}{
+		"sender":               userOp.Sender.Hex(),
+		"nonce":                fmt.Sprintf("0x%s", userOp.Nonce.Text(16)),
+		"callData":             fmt.Sprintf("0x%x", userOp.CallData),
+		"callGasLimit":         fmt.Sprintf("0x%x", userOp.CallGasLimit),
+		"verificationGasLimit": fmt.Sprintf("0x%x", userOp.VerificationGasLimit),
+		"preVerificationGas":   fmt.Sprintf("0x%x", userOp.PreVerificationGas),
+		"maxFeePerGas":         fmt.Sprintf("0x%x", userOp.MaxFeePerGas),
+		"maxPriorityFeePerGas": fmt.Sprintf("0x%x", userOp.MaxPriorityFeePerGas),
+		"signature":            fmt.Sprintf("0x%x", userOp.Signature),
+	}
+
+	if userOp.Factory != nil && !utils.IsZeroAddress(*userOp.Factory) {
+		requestBody["factory"] = userOp.Factory.Hex()
+		requestBody["factoryData"] = fmt.Sprintf("0x%x", userOp.FactoryData)
+	}
+	// a self-paid operation has no paymaster and no paymaster gas limits
+	if userOp.Paymaster != nil && !utils.IsZeroAddress(*userOp.Paymaster) {
+		requestBody["paymaster"] = userOp.Paymaster.Hex()
+		requestBody["paymasterData"] = fmt.Sprintf("0x%x", userOp.PaymasterData)
+		requestBody["paymasterVerificationGasLimit"] = fmt.Sprintf("0x%x", userOp.PaymasterVerificationGasLimit)
+		requestBody["paymasterPostOpGasLimit"] = fmt.Sprintf("0x%x", userOp.PaymasterPostOpGasLimit)
+	}
+	var txHash string
+	err := b.call(&txHash, "eth_sendUserOperation", requestBody, b.epAddress.Hex())
+
+	return SendUserOperationResult{
+		TxHash: txHash,
+	}, err
+}
+
+// GetUserOperationReceipt returns nil while the operation is not yet included.
+func (b *specBundler) GetUserOperationReceipt(opHash string) (*UserOperationReceipt, error) {
+	var result *UserOperationReceipt
+	err := b.call(&result, "eth_getUserOperationReceipt", opHash)
+
+	return result, err
+}
+
+// GetUserOperationByHash returns nil when the bundler does not know the hash.
+func (b *specBundler) GetUserOperationByHash(opHash string) (*UserOperationByHashResult, error) {
+	var result *UserOperationByHashResult
+	err := b.call(&result, "eth_getUserOperationByHash", opHash)
+
+	return result, err
+}
+
+func (b *specBundler) SupportedEntryPoints() ([]common.Address, error) {
+	var result []common.Address
+	err := b.call(&result, "eth_supportedEntryPoints")
+
+	return result, err
+}
+
+type EstimateRequest struct {
+	Sender      string  `json:"sender"`
+	Nonce       string  `json:"nonce"`
+	CallData    string  `json:"callData"`
+	Signature   string  `json:"signature"`
+	Factory     *string `json:"factory,omitempty"`
+	FactoryData *string `json:"factoryData,omitempty"`
+	// set when a paymaster sponsors the operation, so its validation and
+	// postOp are estimated too
+	Paymaster                     *string `json:"paymaster,omitempty"`
+	PaymasterData                 *string `json:"paymasterData,omitempty"`
+	PaymasterVerificationGasLimit *string `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       *string `json:"paymasterPostOpGasLimit,omitempty"`
+}
+
+func newString(s string) *string {
+	return &s
+}
+
+func (b *specBundler) EstimateUserOpGas(userOp model.UserOperation) (EstimateUserOpResult, error) {
+	var requestBody interface{}
+	if b.version == model.EntryPointV06 {
+		requestBody = encodeV06(userOp, b.dummySignature)
+	} else {
+		requestBody = b.estimateRequest(userOp)
+	}
+
+	result := map[string]string{}
+	err := b.call(&result, "eth_estimateUserOperationGas", requestBody, b.epAddress.Hex())
+
+	return EstimateUserOpResult{
+		PreVerificationGas:            big.NewInt(0).SetBytes(common.FromHex(result["preVerificationGas"])),
+		CallGasLimit:                  big.NewInt(0).SetBytes(common.FromHex(result["callGasLimit"])),
+		VerificationGasLimit:          big.NewInt(0).SetBytes(common.FromHex(result["verificationGasLimit"])),
+		PaymasterVerificationGasLimit: optionalGas(result, "paymasterVerificationGasLimit"),
+		PaymasterPostOpGasLimit:       optionalGas(result, "paymasterPostOpGasLimit"),
+	}, err
+}
+
+// optionalGas returns nil when the estimate has no key.
+func optionalGas(result map[string]string, key string) *big.Int {
+	value, ok := result[key]
+	if !ok || value == "" {
+		return nil
+	}
+	return big.NewInt(0).SetBytes(common.FromHex(value))
+}
+
+func (b *specBundler) estimateRequest(userOp model.UserOperation) EstimateRequest {
+	requestBody := EstimateRequest{
+		Sender:    userOp.Sender.Hex(),
+		Nonce:     fmt.Sprintf("0x%s", userOp.Nonce.Text(16)),
+		CallData:  fmt.Sprintf("0x%x", userOp.CallData),
+		Signature: fmt.Sprintf("0x%x", b.dummySignature),
+	}
+
+	if userOp.Factory != nil && !utils.IsZeroAddress(*userOp.Factory) {
+		requestBody.Factory = newString(userOp.Factory.Hex())
+		requestBody.FactoryData = newString(fmt.Sprintf("0x%x", userOp.FactoryData))
+	}
+	if userOp.Paymaster != nil && !utils.IsZeroAddress(*userOp.Paymaster) {
+		requestBody.Paymaster = newString(userOp.Paymaster.Hex())
+		requestBody.PaymasterData = newString(fmt.Sprintf("0x%x", userOp.PaymasterData))
+		if userOp.PaymasterVerificationGasLimit != nil {
+			requestBody.PaymasterVerificationGasLimit = newString(fmt.Sprintf("0x%x", userOp.PaymasterVerificationGasLimit))
+		}
+		if userOp.PaymasterPostOpGasLimit != nil {
+			requestBody.PaymasterPostOpGasLimit = newString(fmt.Sprintf("0x%x", userOp.PaymasterPostOpGasLimit))
+		}
+	}
+	return requestBody
+}
+
+// GetMaxPriorityFeePerGas uses the standard eth_maxPriorityFeePerGas, which
+// spec bundlers proxy to their node.
+func (b *specBundler) GetMaxPriorityFeePerGas() (*big.Int, error) {
+	var result string
+	err := b.call(&result, "eth_maxPriorityFeePerGas")
+	return big.NewInt(0).SetBytes(common.FromHex(result)), err
+}
@@ -0,0 +1,214 @@
+package calldata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ParseFunctionSignature turns a human readable signature such as
+// "transfer(address,uint256)" into an abi.Method. Tuple arguments are not
+// supported.
+func ParseFunctionSignature(signature string) (abi.Method, error) {
+	signature = strings.TrimSpace(signature)
+	signature = strings.TrimPrefix(signature, "function ")
+
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return abi.Method{}, fmt.Errorf("invalid function signature %q", signature)
+	}
+
+	name := strings.TrimSpace(signature[:open])
+	argList := strings.TrimSpace(signature[open+1 : len(signature)-1])
+	if strings.ContainsAny(argList, "()") {
+		return abi.Method{}, errors.New("tuple arguments are not supported")
+	}
+
+	arguments := abi.Arguments{}
+	if argList != "" {
+		for i, arg := range strings.Split(argList, ",") {
+			// allow named parameters, e.g. "address to"
+			fields := strings.Fields(arg)
+			if len(fields) == 0 {
+				return abi.Method{}, fmt.Errorf("empty argument %d in %q", i, signature)
+			}
+			typ, err := abi.NewType(fields[0], "", nil)
+			if err != nil {
+				return abi.Method{}, fmt.Errorf("argument %d: %w", i, err)
+			}
+			arguments = append(arguments, abi.Argument{
+				Name: fmt.Sprintf("arg%d", i),
+				Type: typ,
+			})
+		}
+	}
+
+	return abi.NewMethod(name, name, abi.Function, "", false, false, arguments, nil), nil
+}
+
+// PackFunctionCall encodes a call to signature with args given as JSON
+// values: addresses, bytes and strings as strings, integers as JSON numbers
+// or decimal/0x strings, booleans as booleans and arrays as JSON arrays.
+func PackFunctionCall(signature string, args []json.RawMessage) ([]byte, error) {
+	method, err := ParseFunctionSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != len(method.Inputs) {
+		return nil, fmt.Errorf("%s expects %d arguments, got %d", method.Sig, len(method.Inputs), len(args))
+	}
+
+	values := make([]interface{}, len(args))
+	for i, input := range method.Inputs {
+		value, err := convertArgument(input.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %d (%s): %w", i, input.Type.String(), err)
+		}
+		values[i] = value.Interface()
+	}
+
+	packed, err := method.Inputs.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	return append(method.ID, packed...), nil
+}
+
+func convertArgument(t abi.Type, raw json.RawMessage) (reflect.Value, error) {
+	switch t.T {
+	case abi.AddressTy:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return reflect.Value{}, err
+		}
+		if !common.IsHexAddress(s) {
+			return reflect.Value{}, fmt.Errorf("invalid address %q", s)
+		}
+		return reflect.ValueOf(common.HexToAddress(s)), nil
+
+	case abi.BoolTy:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(b), nil
+
+	case abi.StringTy:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(s), nil
+
+	case abi.BytesTy:
+		b, err := decodeHex(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(b), nil
+
+	case abi.FixedBytesTy:
+		b, err := decodeHex(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if len(b) != t.Size {
+			return reflect.Value{}, fmt.Errorf("expected %d bytes, got %d", t.Size, len(b))
+		}
+		value := reflect.New(t.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(b))
+		return value, nil
+
+	case abi.IntTy, abi.UintTy:
+		n, err := decodeInteger(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		err = checkRange(t, n)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		// above 64 bits the binding takes a *big.Int
+		goType := t.GetType()
+		if goType == reflect.TypeOf(&big.Int{}) {
+			return reflect.ValueOf(n), nil
+		}
+		if t.T == abi.UintTy {
+			return reflect.ValueOf(n.Uint64()).Convert(goType), nil
+		}
+		return reflect.ValueOf(n.Int64()).Convert(goType), nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return reflect.Value{}, err
+		}
+
+		var value reflect.Value
+		if t.T == abi.ArrayTy {
+			if len(items) != t.Size {
+				return reflect.Value{}, fmt.Errorf("expected %d items, got %d", t.Size, len(items))
+			}
+			value = reflect.New(t.GetType()).Elem()
+		} else {
+			value = reflect.MakeSlice(t.GetType(), len(items), len(items))
+		}
+
+		for i, item := range items {
+			elem, err := convertArgument(*t.Elem, item)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("item %d: %w", i, err)
+			}
+			value.Index(i).Set(elem)
+		}
+		return value, nil
+
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %s", t.String())
+	}
+}
+
+// checkRange rejects n outside the values of t: [0, 2^size) for uintN and
+// [-2^(size-1), 2^(size-1)) for intN.
+func checkRange(t abi.Type, n *big.Int) error {
+	if t.T == abi.UintTy {
+		if n.Sign() < 0 {
+			return errors.New("negative value for unsigned integer")
+		}
+		if n.BitLen() > t.Size {
+			return fmt.Errorf("value overflows %s", t.String())
+		}
+		return nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+	if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return fmt.Errorf("value overflows %s", t.String())
+	}
+	return nil
+}
+
+func decodeHex(raw json.RawMessage) ([]byte, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	return hexutil.Decode(s)
+}
+
+func decodeInteger(raw json.RawMessage) (*big.Int, error) {
+	s := strings.Trim(strings.TrimSpace(string(raw)), `"`)
+
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %s", string(raw))
+	}
+	return n, nil
+}
@@ -0,0 +1,56 @@
+package calldata
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPackFunctionCallIntegerRange(t *testing.T) {
+	tests := []struct {
+		signature string
+		arg       string
+		// the encoded argument word, empty when the value must be rejected
+		want string
+	}{
+		{"f(int8)", `127`, "0x7f"},
+		{"f(int8)", `-128`, "-0x80"},
+		{"f(int8)", `128`, ""},
+		{"f(int8)", `-129`, ""},
+		{"f(int8)", `-200`, ""},
+		{"f(int64)", `"9223372036854775807"`, "0x7fffffffffffffff"},
+		{"f(int64)", `"9223372036854775808"`, ""},
+		{"f(int64)", `"-9223372036854775808"`, "-0x8000000000000000"},
+		{"f(int128)", `"-170141183460469231731687303715884105728"`, "-0x80000000000000000000000000000000"},
+		{"f(int128)", `"170141183460469231731687303715884105728"`, ""},
+		{"f(uint8)", `255`, "0xff"},
+		{"f(uint8)", `256`, ""},
+		{"f(uint8)", `-1`, ""},
+		{"f(uint256)", `"0x` + "ff" + `"`, "0xff"},
+	}
+	for _, tt := range tests {
+		packed, err := PackFunctionCall(tt.signature, []json.RawMessage{json.RawMessage(tt.arg)})
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("%s %s: packed %x, want an error", tt.signature, tt.arg, packed)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %s: %v", tt.signature, tt.arg, err)
+			continue
+		}
+
+		want, _ := new(big.Int).SetString(tt.want, 0)
+		word := common.BytesToHash(packed[4:]).Big()
+		if want.Sign() < 0 {
+			// two's complement over the 256 bit word
+			want.Add(want, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		if word.Cmp(want) != 0 {
+			t.Errorf("%s %s: encoded %#x, want %#x", tt.signature, tt.arg, word, want)
+		}
+	}
+}
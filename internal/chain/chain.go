@@ -0,0 +1,277 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"web3-account-abstraction-api/generated/abi/accountfactory"
+	"web3-account-abstraction-api/generated/abi/entrypoint"
+	"web3-account-abstraction-api/generated/abi/entrypointv06"
+	"web3-account-abstraction-api/generated/abi/paymaster"
+	"web3-account-abstraction-api/generated/abi/paymasterv06"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/bundler"
+	contract "web3-account-abstraction-api/internal/contracts"
+	"web3-account-abstraction-api/internal/fee"
+	"web3-account-abstraction-api/internal/gas"
+	"web3-account-abstraction-api/internal/keys"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/policy"
+	"web3-account-abstraction-api/internal/pricing"
+	"web3-account-abstraction-api/internal/signer"
+	"web3-account-abstraction-api/internal/store"
+	"web3-account-abstraction-api/internal/usecase"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	ErrUnknownChain = apperr.New(apperr.KindNotFound, "unknown_chain", "unknown chain")
+)
+
+// Config describes one deployment: where to reach the chain and bundler, the
+// ERC-4337 contracts and the keys used on it.
+type Config struct {
+	ChainID                   int64  `mapstructure:"chain_id" json:"chainId"`
+	Name                      string `mapstructure:"name" json:"name"`
+	RPCURL                    string `mapstructure:"rpc_url" json:"rpcUrl"`
+	BundlerURL                string `mapstructure:"bundler_url" json:"bundlerUrl"`
+	BundlerProvider           string `mapstructure:"bundler_provider" json:"bundlerProvider"`
+	EntryPointAddress         string `mapstructure:"entrypoint_address" json:"entryPointAddress"`
+	EntryPointVersion         string `mapstructure:"entrypoint_version" json:"entryPointVersion"`
+	AccountFactoryAddress     string `mapstructure:"account_factory_address" json:"accountFactoryAddress"`
+	PaymasterAddress          string `mapstructure:"paymaster_address" json:"paymasterAddress"`
+	PrivateKey                string `mapstructure:"private_key" json:"-"`
+	PaymasterSignerPrivateKey string `mapstructure:"paymaster_signer_private_key" json:"-"`
+	// defaults to PrivateKey
+	PaymasterOwnerPrivateKey string `mapstructure:"paymaster_owner_private_key" json:"-"`
+	// take precedence over the private keys above
+	Signers Signers `mapstructure:"signers" json:"-"`
+	// wei; the deposit monitor alerts when the paymaster's EntryPoint
+	// deposit drops below it, disabled when empty
+	PaymasterDepositThreshold string `mapstructure:"paymaster_deposit_threshold" json:"paymasterDepositThreshold"`
+	// ERC-20 the paymaster charges in; defaults to the v0.7 paymaster, which
+	// is its own token, and to none for v0.6
+	PaymasterToken string `mapstructure:"paymaster_token" json:"paymasterToken"`
+	// prices the paymaster token in ether
+	Pricing pricing.Config `mapstructure:"pricing" json:"-"`
+	// how user operation fees are suggested
+	Fees fee.Config `mapstructure:"fees" json:"-"`
+	// markups applied to the bundler's gas estimates
+	Gas gas.Config `mapstructure:"gas" json:"-"`
+}
+
+// Signers configures the key of each role, see signer.Config.
+type Signers struct {
+	Owner           signer.Config `mapstructure:"owner"`
+	PaymasterSigner signer.Config `mapstructure:"paymaster_signer"`
+	PaymasterOwner  signer.Config `mapstructure:"paymaster_owner"`
+}
+
+// signerConfigs resolves the signer of every role, falling back to the
+// private keys. The paymaster owner defaults to the owner.
+func (cfg Config) signerConfigs() Signers {
+	signers := cfg.Signers
+	if signers.Owner.IsZero() {
+		signers.Owner = signer.Config{Key: cfg.PrivateKey}
+	}
+	if signers.PaymasterSigner.IsZero() {
+		signers.PaymasterSigner = signer.Config{Key: cfg.PaymasterSignerPrivateKey}
+	}
+	if signers.PaymasterOwner.IsZero() {
+		signers.PaymasterOwner = signers.Owner
+		if cfg.PaymasterOwnerPrivateKey != "" {
+			signers.PaymasterOwner = signer.Config{Key: cfg.PaymasterOwnerPrivateKey}
+		}
+	}
+	return signers
+}
+
+type Chain struct {
+	ID        int64
+	Config    Config
+	Client    *ethclient.Client
+	Contracts contract.Contracts
+	Bundler   bundler.Bundler
+	Fees      *fee.Oracle
+	Usecase   usecase.Usecase
+}
+
+// Connect dials the chain and bundler of cfg and wires its contracts and
+// usecase. A zero cfg.ChainID is taken from the RPC; otherwise the RPC must
+// report the same id. masterKey may be nil, see usecase.NewUseCase.
+func Connect(cfg Config, store store.Store, masterKey *keys.MasterKey) (*Chain, error) {
+	client, err := ethclient.Dial(cfg.RPCURL)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ChainID != 0 && chainId.Cmp(big.NewInt(cfg.ChainID)) != 0 {
+		return nil, fmt.Errorf("chain %d: rpc reports chain id %s", cfg.ChainID, chainId)
+	}
+	cfg.ChainID = chainId.Int64()
+
+	signers := cfg.signerConfigs()
+	owner, err := signer.New(signers.Owner)
+	if err != nil {
+		return nil, fmt.Errorf("owner signer: %w", err)
+	}
+	paymasterSigner, err := signer.New(signers.PaymasterSigner)
+	if err != nil {
+		return nil, fmt.Errorf("paymaster signer: %w", err)
+	}
+	paymasterOwner, err := signer.New(signers.PaymasterOwner)
+	if err != nil {
+		return nil, fmt.Errorf("paymaster owner signer: %w", err)
+	}
+
+	epVersion, err := model.ParseEntryPointVersion(cfg.EntryPointVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	afAddress := common.HexToAddress(cfg.AccountFactoryAddress)
+	epAddress := common.HexToAddress(cfg.EntryPointAddress)
+	pmAddress := common.HexToAddress(cfg.PaymasterAddress)
+
+	af, err := accountfactory.NewAccountFactory(afAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := contract.Contracts{
+		AccountFactory:    af,
+		EntryPointVersion: epVersion,
+
+		EntryPointAddress:     epAddress,
+		PaymasterAddress:      pmAddress,
+		AccountFactoryAddress: afAddress,
+	}
+
+	if epVersion == model.EntryPointV06 {
+		contracts.EntryPointV06, err = entrypointv06.NewEntryPointV06(epAddress, client)
+		if err != nil {
+			return nil, err
+		}
+		contracts.PaymasterV06, err = paymasterv06.NewPaymasterV06(pmAddress, client)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		contracts.EntryPoint, err = entrypoint.NewEntryPoint(epAddress, client)
+		if err != nil {
+			return nil, err
+		}
+		contracts.Paymaster, err = paymaster.NewPaymaster(pmAddress, client)
+		if err != nil {
+			return nil, err
+		}
+		contracts.Token = &pmAddress
+	}
+	if cfg.PaymasterToken != "" {
+		token := common.HexToAddress(cfg.PaymasterToken)
+		contracts.Token = &token
+	}
+
+	contracts.SetChainId(chainId)
+	contracts.SetOwnerSigner(owner)
+	contracts.SetRPCClient(client)
+	contracts.SetPaymasterSigner(paymasterSigner)
+	contracts.SetPaymasterOwnerSigner(paymasterOwner)
+
+	bundlerURL := cfg.BundlerURL
+	if bundlerURL == "" {
+		bundlerURL = cfg.RPCURL
+	}
+	bundlerClient, err := rpc.Dial(bundlerURL)
+	if err != nil {
+		return nil, err
+	}
+	b, err := bundler.New(cfg.BundlerProvider, epVersion, bundlerClient, epAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// only asked for a price when the paymaster charges in a token
+	var token common.Address
+	if contracts.Token != nil {
+		token = *contracts.Token
+	}
+	price, err := pricing.New(cfg.Pricing, client, token)
+	if err != nil {
+		return nil, fmt.Errorf("price source: %w", err)
+	}
+
+	fees, err := fee.New(cfg.Fees, client, b)
+	if err != nil {
+		return nil, fmt.Errorf("fees: %w", err)
+	}
+	markups, err := gas.New(cfg.Gas)
+	if err != nil {
+		return nil, fmt.Errorf("gas: %w", err)
+	}
+	estimator := gas.NewEstimator(cfg.Gas, cfg.ChainID, b, gas.NewSimulator(client, contracts))
+
+	return &Chain{
+		ID:        cfg.ChainID,
+		Config:    cfg,
+		Client:    client,
+		Contracts: contracts,
+		Bundler:   b,
+		Fees:      fees,
+		Usecase:   usecase.NewUseCase(contracts, b, client, store, policy.NewEngine(store, cfg.ChainID), price, fees, markups, estimator, masterKey),
+	}, nil
+}
+
+// Registry holds every connected chain. The first registered chain is the
+// default, served by the unscoped routes.
+type Registry struct {
+	chains    map[int64]*Chain
+	defaultID int64
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		chains: map[int64]*Chain{},
+	}
+}
+
+func (r *Registry) Register(c *Chain) error {
+	if _, ok := r.chains[c.ID]; ok {
+		return fmt.Errorf("chain %d registered twice", c.ID)
+	}
+	if len(r.chains) == 0 {
+		r.defaultID = c.ID
+	}
+	r.chains[c.ID] = c
+	return nil
+}
+
+func (r *Registry) Get(id int64) (*Chain, error) {
+	c, ok := r.chains[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownChain, id)
+	}
+	return c, nil
+}
+
+func (r *Registry) Default() (*Chain, error) {
+	return r.Get(r.defaultID)
+}
+
+// All returns the chains ordered by id.
+func (r *Registry) All() []*Chain {
+	result := make([]*Chain, 0, len(r.chains))
+	for _, c := range r.chains {
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
@@ -0,0 +1,112 @@
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"web3-account-abstraction-api/internal/bundler"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/signer"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/viper"
+)
+
+// LoadConfigs reads the "chains" list from a JSON, YAML or TOML file.
+func LoadConfigs(path string) ([]Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	err := v.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []Config
+	err = v.UnmarshalKey("chains", &configs)
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return nil, errors.New("no chains configured")
+	}
+	return configs, nil
+}
+
+// Validate checks cfg without dialing anything.
+func (cfg Config) Validate() error {
+	if cfg.RPCURL == "" {
+		return errors.New("rpc url is required")
+	}
+	addresses := []struct{ name, address string }{
+		{"entry point", cfg.EntryPointAddress},
+		{"account factory", cfg.AccountFactoryAddress},
+		{"paymaster", cfg.PaymasterAddress},
+	}
+	for _, a := range addresses {
+		if !common.IsHexAddress(a.address) {
+			return fmt.Errorf("invalid %s address %q", a.name, a.address)
+		}
+	}
+
+	if cfg.PaymasterToken != "" && !common.IsHexAddress(cfg.PaymasterToken) {
+		return fmt.Errorf("invalid paymaster token address %q", cfg.PaymasterToken)
+	}
+
+	_, err := model.ParseEntryPointVersion(cfg.EntryPointVersion)
+	if err != nil {
+		return err
+	}
+	switch cfg.BundlerProvider {
+	case "", bundler.ProviderRundler, bundler.ProviderPimlico, bundler.ProviderGeneric:
+	default:
+		return fmt.Errorf("unknown bundler provider %q", cfg.BundlerProvider)
+	}
+
+	_, err = cfg.DepositThreshold()
+	if err != nil {
+		return err
+	}
+	err = cfg.Pricing.Validate()
+	if err != nil {
+		return fmt.Errorf("pricing: %w", err)
+	}
+	err = cfg.Fees.Validate()
+	if err != nil {
+		return fmt.Errorf("fees: %w", err)
+	}
+	err = cfg.Gas.Validate()
+	if err != nil {
+		return fmt.Errorf("gas: %w", err)
+	}
+
+	signers := cfg.signerConfigs()
+	roles := []struct {
+		name   string
+		config signer.Config
+	}{
+		{"owner", signers.Owner},
+		{"paymaster signer", signers.PaymasterSigner},
+		{"paymaster owner", signers.PaymasterOwner},
+	}
+	for _, role := range roles {
+		err = role.config.Validate()
+		if err != nil {
+			return fmt.Errorf("%s signer: %w", role.name, err)
+		}
+	}
+	return nil
+}
+
+// DepositThreshold parses PaymasterDepositThreshold, nil when unset.
+func (cfg Config) DepositThreshold() (*big.Int, error) {
+	if cfg.PaymasterDepositThreshold == "" {
+		return nil, nil
+	}
+	threshold, ok := new(big.Int).SetString(cfg.PaymasterDepositThreshold, 10)
+	if !ok || threshold.Sign() < 0 {
+		return nil, fmt.Errorf("invalid paymaster deposit threshold %q", cfg.PaymasterDepositThreshold)
+	}
+	return threshold, nil
+}
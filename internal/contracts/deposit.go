@@ -0,0 +1,34 @@
+package contract
+
+import (
+	"math/big"
+	"web3-account-abstraction-api/generated/abi/entrypoint"
+	"web3-account-abstraction-api/generated/abi/entrypointv06"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// FundDeposit adds amount wei to the EntryPoint deposit of account, paid by
+// the default owner.
+func (c *Contracts) FundDeposit(account Address, amount *big.Int) (*types.Transaction, error) {
+	auth, err := c.signerTransactOpts(c.owner, amount)
+	if err != nil {
+		return nil, err
+	}
+	if c.EntryPointVersion == model.EntryPointV06 {
+		return c.EntryPointV06.DepositTo(auth, account)
+	}
+	return c.EntryPoint.DepositTo(auth, account)
+}
+
+// GetWithdrawToCallData is the EntryPoint call withdrawing amount wei of the
+// caller's deposit to the given address.
+func (c *Contracts) GetWithdrawToCallData(to Address, amount *big.Int) ([]byte, error) {
+	if c.EntryPointVersion == model.EntryPointV06 {
+		abi, _ := entrypointv06.EntryPointV06MetaData.GetAbi()
+		return abi.Pack("withdrawTo", to, amount)
+	}
+	abi, _ := entrypoint.EntryPointMetaData.GetAbi()
+	return abi.Pack("withdrawTo", to, amount)
+}
@@ -0,0 +1,112 @@
+package contract
+
+import (
+	"math/big"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// GetDepositInfo reads the deposit and stake of account in the EntryPoint.
+func (c *Contracts) GetDepositInfo(account Address) (model.DepositInfo, error) {
+	opts := &bind.CallOpts{Pending: false}
+
+	if c.EntryPointVersion == model.EntryPointV06 {
+		info, err := c.EntryPointV06.GetDepositInfo(opts, account)
+		if err != nil {
+			return model.DepositInfo{}, err
+		}
+		return model.DepositInfo{
+			Address:         account,
+			Deposit:         info.Deposit,
+			Staked:          info.Staked,
+			Stake:           info.Stake,
+			UnstakeDelaySec: info.UnstakeDelaySec,
+			WithdrawTime:    info.WithdrawTime,
+		}, nil
+	}
+
+	info, err := c.EntryPoint.GetDepositInfo(opts, account)
+	if err != nil {
+		return model.DepositInfo{}, err
+	}
+	return model.DepositInfo{
+		Address:         account,
+		Deposit:         info.Deposit,
+		Staked:          info.Staked,
+		Stake:           info.Stake,
+		UnstakeDelaySec: info.UnstakeDelaySec,
+		WithdrawTime:    info.WithdrawTime,
+	}, nil
+}
+
+// GetPaymasterDepositInfo reads the paymaster's deposit and stake.
+func (c *Contracts) GetPaymasterDepositInfo() (model.DepositInfo, error) {
+	return c.GetDepositInfo(c.PaymasterAddress)
+}
+
+// The paymaster only lets its owner withdraw and stake, so every transaction
+// below is sent by the paymaster owner.
+
+// DepositPaymaster adds amount wei to the paymaster's EntryPoint deposit.
+func (c *Contracts) DepositPaymaster(amount *big.Int) (*types.Transaction, error) {
+	auth, err := c.signerTransactOpts(c.paymasterOwner, amount)
+	if err != nil {
+		return nil, err
+	}
+	if c.EntryPointVersion == model.EntryPointV06 {
+		return c.PaymasterV06.Deposit(auth)
+	}
+	return c.Paymaster.Deposit(auth)
+}
+
+// WithdrawPaymasterDeposit withdraws amount wei of the paymaster's deposit
+// to the given address.
+func (c *Contracts) WithdrawPaymasterDeposit(to Address, amount *big.Int) (*types.Transaction, error) {
+	auth, err := c.signerTransactOpts(c.paymasterOwner, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.EntryPointVersion == model.EntryPointV06 {
+		return c.PaymasterV06.WithdrawTo(auth, to, amount)
+	}
+	return c.Paymaster.WithdrawTo(auth, to, amount)
+}
+
+// AddPaymasterStake stakes amount wei for the paymaster, locked for at least
+// unstakeDelaySec once unlocked.
+func (c *Contracts) AddPaymasterStake(unstakeDelaySec uint32, amount *big.Int) (*types.Transaction, error) {
+	auth, err := c.signerTransactOpts(c.paymasterOwner, amount)
+	if err != nil {
+		return nil, err
+	}
+	if c.EntryPointVersion == model.EntryPointV06 {
+		return c.PaymasterV06.AddStake(auth, unstakeDelaySec)
+	}
+	return c.Paymaster.AddStake(auth, unstakeDelaySec)
+}
+
+// UnlockPaymasterStake starts the unstake delay of the paymaster's stake.
+func (c *Contracts) UnlockPaymasterStake() (*types.Transaction, error) {
+	auth, err := c.signerTransactOpts(c.paymasterOwner, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.EntryPointVersion == model.EntryPointV06 {
+		return c.PaymasterV06.UnlockStake(auth)
+	}
+	return c.Paymaster.UnlockStake(auth)
+}
+
+// WithdrawPaymasterStake withdraws the unlocked stake to the given address.
+func (c *Contracts) WithdrawPaymasterStake(to Address) (*types.Transaction, error) {
+	auth, err := c.signerTransactOpts(c.paymasterOwner, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.EntryPointVersion == model.EntryPointV06 {
+		return c.PaymasterV06.WithdrawStake(auth, to)
+	}
+	return c.Paymaster.WithdrawStake(auth, to)
+}
@@ -0,0 +1,69 @@
+package contract
+
+import (
+	"math/big"
+	"web3-account-abstraction-api/generated/abi/erc20"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TokenAddress is the ERC-20 the paymaster charges in, nil when it only
+// sponsors.
+func (c *Contracts) TokenAddress() *Address {
+	return c.Token
+}
+
+// PaymasterIsToken reports whether the paymaster charges in its own token,
+// which it can mint and take without an allowance.
+func (c *Contracts) PaymasterIsToken() bool {
+	return c.Token != nil && *c.Token == c.PaymasterAddress
+}
+
+// GetCostOfPost is the gas the token paymaster adds for its postOp; the
+// v0.6 paymaster does not declare any.
+func (c *Contracts) GetCostOfPost() (*big.Int, error) {
+	if c.Paymaster == nil {
+		return big.NewInt(0), nil
+	}
+	return c.Paymaster.COSTOFPOST(&bind.CallOpts{Pending: false})
+}
+
+func (c *Contracts) GetERC20Allowance(owner Address, spender Address, tokenAddr Address) (*big.Int, error) {
+	erc, err := erc20.NewERC20(tokenAddr, c.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return erc.Allowance(&bind.CallOpts{
+		Pending: false,
+	}, owner, spender)
+}
+
+// TokenCharged sums the transfers of token from sender to paymaster in logs,
+// which is what the paymaster took for an operation when logs are its
+// receipt's. Transfers the operation itself makes to anyone else are not
+// counted.
+func TokenCharged(logs []types.Log, token Address, sender Address, paymaster Address) (*big.Int, error) {
+	filterer, err := erc20.NewERC20Filterer(token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	transferTopic := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	charged := new(big.Int)
+	for _, log := range logs {
+		if log.Address != token || len(log.Topics) != 3 || log.Topics[0] != transferTopic {
+			continue
+		}
+		transfer, err := filterer.ParseTransfer(log)
+		if err != nil {
+			return nil, err
+		}
+		if transfer.From == sender && transfer.To == paymaster {
+			charged.Add(charged, transfer.Value)
+		}
+	}
+	return charged, nil
+}
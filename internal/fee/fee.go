@@ -0,0 +1,155 @@
+package fee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"web3-account-abstraction-api/internal/bundler"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	StrategyFeeHistory = "fee_history"
+	StrategyBundler    = "bundler"
+	StrategyFixed      = "fixed"
+)
+
+// Config selects the strategy suggesting the fees of a chain and the caps
+// applied to whatever it suggests.
+type Config struct {
+	// fee_history (default), bundler or fixed
+	Strategy string `mapstructure:"strategy"`
+	// fee_history: blocks sampled, defaults to 20
+	BlockCount uint64 `mapstructure:"block_count"`
+	// fee_history: priority fee percentiles of the slow, normal and fast
+	// tiers, defaults to 10, 50 and 90
+	Percentiles []float64 `mapstructure:"percentiles"`
+	// fixed: wei, used for every tier
+	MaxFeePerGas         string `mapstructure:"max_fee_per_gas"`
+	MaxPriorityFeePerGas string `mapstructure:"max_priority_fee_per_gas"`
+	// wei, no cap when empty
+	MaxFeeCap         string `mapstructure:"max_fee_cap"`
+	MaxPriorityFeeCap string `mapstructure:"max_priority_fee_cap"`
+}
+
+// strategy suggests the fees of one tier.
+type strategy interface {
+	name() string
+	suggest(ctx context.Context, tier model.FeeTier) (model.Fees, error)
+}
+
+// Oracle suggests EIP-1559 fees for user operations.
+type Oracle struct {
+	strategy          strategy
+	maxFeeCap         *big.Int
+	maxPriorityFeeCap *big.Int
+}
+
+func New(cfg Config, client *ethclient.Client, b bundler.Bundler) (*Oracle, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	oracle := &Oracle{}
+	oracle.maxFeeCap, _ = parseWei(cfg.MaxFeeCap)
+	oracle.maxPriorityFeeCap, _ = parseWei(cfg.MaxPriorityFeeCap)
+
+	switch cfg.Strategy {
+	case StrategyFeeHistory, "":
+		oracle.strategy = newFeeHistoryStrategy(client, cfg.BlockCount, cfg.Percentiles)
+	case StrategyBundler:
+		oracle.strategy = &bundlerStrategy{client: client, bundler: b}
+	case StrategyFixed:
+		maxFee, _ := parseWei(cfg.MaxFeePerGas)
+		maxPriorityFee, _ := parseWei(cfg.MaxPriorityFeePerGas)
+		oracle.strategy = &fixedStrategy{maxFee: maxFee, maxPriorityFee: maxPriorityFee}
+	}
+	return oracle, nil
+}
+
+// Validate checks cfg without contacting the chain.
+func (cfg Config) Validate() error {
+	switch cfg.Strategy {
+	case StrategyFeeHistory, "":
+		if len(cfg.Percentiles) != 0 && len(cfg.Percentiles) != 3 {
+			return errors.New("fee history needs the percentiles of the slow, normal and fast tiers")
+		}
+		for i, percentile := range cfg.Percentiles {
+			if percentile < 0 || percentile > 100 || (i > 0 && percentile < cfg.Percentiles[i-1]) {
+				return fmt.Errorf("invalid fee history percentiles %v", cfg.Percentiles)
+			}
+		}
+	case StrategyBundler:
+	case StrategyFixed:
+		if cfg.MaxFeePerGas == "" || cfg.MaxPriorityFeePerGas == "" {
+			return errors.New("fixed fees need max_fee_per_gas and max_priority_fee_per_gas")
+		}
+	default:
+		return fmt.Errorf("unknown fee strategy %q", cfg.Strategy)
+	}
+
+	for _, value := range []string{cfg.MaxFeePerGas, cfg.MaxPriorityFeePerGas, cfg.MaxFeeCap, cfg.MaxPriorityFeeCap} {
+		_, err := parseWei(value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Suggest returns the fees of tier, capped.
+func (o *Oracle) Suggest(ctx context.Context, tier model.FeeTier) (model.Fees, error) {
+	fees, err := o.strategy.suggest(ctx, tier)
+	if err != nil {
+		return model.Fees{}, fmt.Errorf("%s fees: %w", o.strategy.name(), err)
+	}
+	fees.Tier = tier
+	fees.Strategy = o.strategy.name()
+
+	if o.maxFeeCap != nil && fees.MaxFeePerGas.Cmp(o.maxFeeCap) > 0 {
+		fees.MaxFeePerGas = new(big.Int).Set(o.maxFeeCap)
+	}
+	if o.maxPriorityFeeCap != nil && fees.MaxPriorityFeePerGas.Cmp(o.maxPriorityFeeCap) > 0 {
+		fees.MaxPriorityFeePerGas = new(big.Int).Set(o.maxPriorityFeeCap)
+	}
+	// the priority fee is paid out of the max fee
+	if fees.MaxPriorityFeePerGas.Cmp(fees.MaxFeePerGas) > 0 {
+		fees.MaxPriorityFeePerGas = new(big.Int).Set(fees.MaxFeePerGas)
+	}
+	return fees, nil
+}
+
+// SuggestAll returns the fees of every tier.
+func (o *Oracle) SuggestAll(ctx context.Context) (map[model.FeeTier]model.Fees, error) {
+	result := map[model.FeeTier]model.Fees{}
+	for _, tier := range []model.FeeTier{model.FeeTierSlow, model.FeeTierNormal, model.FeeTierFast} {
+		fees, err := o.Suggest(ctx, tier)
+		if err != nil {
+			return nil, err
+		}
+		result[tier] = fees
+	}
+	return result, nil
+}
+
+// maxFeeFor leaves room for the base fee to double before inclusion, the
+// usual EIP-1559 headroom.
+func maxFeeFor(baseFee *big.Int, maxPriorityFee *big.Int) *big.Int {
+	maxFee := new(big.Int).Mul(baseFee, big.NewInt(2))
+	return maxFee.Add(maxFee, maxPriorityFee)
+}
+
+func parseWei(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	value, ok := new(big.Int).SetString(s, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid wei amount %q", s)
+	}
+	return value, nil
+}
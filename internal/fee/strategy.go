@@ -0,0 +1,173 @@
+package fee
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"web3-account-abstraction-api/internal/bundler"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const defaultBlockCount = 20
+
+var defaultPercentiles = []float64{10, 50, 90}
+
+// feeHistoryStrategy takes the priority fee of the tier's percentile over
+// recent blocks and the next block's base fee from eth_feeHistory.
+type feeHistoryStrategy struct {
+	client      *ethclient.Client
+	blockCount  uint64
+	percentiles []float64
+}
+
+func newFeeHistoryStrategy(client *ethclient.Client, blockCount uint64, percentiles []float64) *feeHistoryStrategy {
+	if blockCount == 0 {
+		blockCount = defaultBlockCount
+	}
+	if len(percentiles) == 0 {
+		percentiles = defaultPercentiles
+	}
+	return &feeHistoryStrategy{client: client, blockCount: blockCount, percentiles: percentiles}
+}
+
+func (s *feeHistoryStrategy) name() string {
+	return StrategyFeeHistory
+}
+
+func (s *feeHistoryStrategy) suggest(ctx context.Context, tier model.FeeTier) (model.Fees, error) {
+	history, err := s.client.FeeHistory(ctx, s.blockCount, nil, s.percentiles)
+	if err != nil {
+		return model.Fees{}, err
+	}
+	if len(history.BaseFee) == 0 {
+		return model.Fees{}, errors.New("eth_feeHistory returned no base fee")
+	}
+	// the last base fee is the one of the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	index := tierIndex(tier)
+	rewards := make([]*big.Int, 0, len(history.Reward))
+	for _, blockRewards := range history.Reward {
+		if index < len(blockRewards) && blockRewards[index] != nil {
+			rewards = append(rewards, blockRewards[index])
+		}
+	}
+	maxPriorityFee := median(rewards)
+
+	return model.Fees{
+		BaseFee:              baseFee,
+		MaxFeePerGas:         maxFeeFor(baseFee, maxPriorityFee),
+		MaxPriorityFeePerGas: maxPriorityFee,
+	}, nil
+}
+
+// gasPricer is implemented by bundlers suggesting fees per tier, such as
+// pimlico.
+type gasPricer interface {
+	GetUserOperationGasPrice() (bundler.UserOperationGasPrice, error)
+}
+
+// bundler tiers scale its single priority fee suggestion, in percent
+var bundlerTierMarkup = map[model.FeeTier]int64{
+	model.FeeTierSlow:   100,
+	model.FeeTierNormal: 110,
+	model.FeeTierFast:   125,
+}
+
+// bundlerStrategy asks the bundler, which knows the fees it accepts. Tiered
+// bundlers answer per tier; otherwise the suggested priority fee is marked up
+// per tier on top of the latest base fee.
+type bundlerStrategy struct {
+	client  *ethclient.Client
+	bundler bundler.Bundler
+}
+
+func (s *bundlerStrategy) name() string {
+	return StrategyBundler
+}
+
+func (s *bundlerStrategy) suggest(ctx context.Context, tier model.FeeTier) (model.Fees, error) {
+	if pricer, ok := s.bundler.(gasPricer); ok {
+		gasPrice, err := pricer.GetUserOperationGasPrice()
+		if err != nil {
+			return model.Fees{}, err
+		}
+		price := gasPrice.Standard
+		switch tier {
+		case model.FeeTierSlow:
+			price = gasPrice.Slow
+		case model.FeeTierFast:
+			price = gasPrice.Fast
+		}
+		if price.MaxFeePerGas == nil || price.MaxPriorityFeePerGas == nil {
+			return model.Fees{}, errors.New("bundler returned no gas price for the tier")
+		}
+		return model.Fees{
+			MaxFeePerGas:         price.MaxFeePerGas.ToInt(),
+			MaxPriorityFeePerGas: price.MaxPriorityFeePerGas.ToInt(),
+		}, nil
+	}
+
+	suggested, err := s.bundler.GetMaxPriorityFeePerGas()
+	if err != nil {
+		return model.Fees{}, err
+	}
+	header, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return model.Fees{}, err
+	}
+	if header.BaseFee == nil {
+		return model.Fees{}, errors.New("chain has no base fee")
+	}
+
+	maxPriorityFee := new(big.Int).Mul(suggested, big.NewInt(bundlerTierMarkup[tier]))
+	maxPriorityFee.Div(maxPriorityFee, big.NewInt(100))
+	return model.Fees{
+		BaseFee:              header.BaseFee,
+		MaxFeePerGas:         maxFeeFor(header.BaseFee, maxPriorityFee),
+		MaxPriorityFeePerGas: maxPriorityFee,
+	}, nil
+}
+
+// fixedStrategy uses configured fees whatever the tier.
+type fixedStrategy struct {
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+}
+
+func (s *fixedStrategy) name() string {
+	return StrategyFixed
+}
+
+func (s *fixedStrategy) suggest(ctx context.Context, tier model.FeeTier) (model.Fees, error) {
+	return model.Fees{
+		MaxFeePerGas:         new(big.Int).Set(s.maxFee),
+		MaxPriorityFeePerGas: new(big.Int).Set(s.maxPriorityFee),
+	}, nil
+}
+
+func tierIndex(tier model.FeeTier) int {
+	switch tier {
+	case model.FeeTierSlow:
+		return 0
+	case model.FeeTierFast:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func median(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return big.NewInt(0)
+	}
+	sorted := make([]*big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	return new(big.Int).Set(sorted[len(sorted)/2])
+}
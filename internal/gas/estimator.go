@@ -0,0 +1,117 @@
+package gas
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"web3-account-abstraction-api/internal/bundler"
+	"web3-account-abstraction-api/internal/model"
+)
+
+const (
+	SimulationOff        = "off"
+	SimulationFallback   = "fallback"
+	SimulationCrossCheck = "cross_check"
+)
+
+// DefaultCrossCheckTolerance is the percent a bundler estimate may differ from
+// the simulated one before it is logged.
+const DefaultCrossCheckTolerance = 20
+
+// Estimator estimates the gas of a user operation; bundler.Bundler is one.
+type Estimator interface {
+	EstimateUserOpGas(userOp model.UserOperation) (bundler.EstimateUserOpResult, error)
+}
+
+// NewEstimator returns the estimator cfg.Simulation selects: the bundler
+// alone, the bundler falling back to simulator, or both with the larger
+// estimate of each field kept.
+func NewEstimator(cfg Config, chainId int64, b bundler.Bundler, simulator *Simulator) Estimator {
+	switch cfg.Simulation {
+	case SimulationFallback:
+		return &fallbackEstimator{chainId: chainId, bundler: b, simulator: simulator}
+	case SimulationCrossCheck:
+		tolerance := cfg.CrossCheckTolerance
+		if tolerance == 0 {
+			tolerance = DefaultCrossCheckTolerance
+		}
+		return &crossCheckEstimator{chainId: chainId, bundler: b, simulator: simulator, tolerance: tolerance}
+	}
+	return b
+}
+
+type fallbackEstimator struct {
+	chainId   int64
+	bundler   bundler.Bundler
+	simulator *Simulator
+}
+
+func (e *fallbackEstimator) EstimateUserOpGas(userOp model.UserOperation) (bundler.EstimateUserOpResult, error) {
+	result, err := e.bundler.EstimateUserOpGas(userOp)
+	if err == nil {
+		return result, nil
+	}
+
+	log.Printf("gas (chain %d): bundler cannot estimate %s, simulating: %v", e.chainId, userOp.Sender, err)
+	simulated, simulationErr := e.simulator.EstimateUserOpGas(userOp)
+	if simulationErr != nil {
+		return bundler.EstimateUserOpResult{}, fmt.Errorf("%w (simulation: %w)", err, simulationErr)
+	}
+	return simulated, nil
+}
+
+type crossCheckEstimator struct {
+	chainId   int64
+	bundler   bundler.Bundler
+	simulator *Simulator
+	tolerance uint64
+}
+
+func (e *crossCheckEstimator) EstimateUserOpGas(userOp model.UserOperation) (bundler.EstimateUserOpResult, error) {
+	result, err := e.bundler.EstimateUserOpGas(userOp)
+	simulated, simulationErr := e.simulator.EstimateUserOpGas(userOp)
+	switch {
+	case err != nil && simulationErr != nil:
+		return bundler.EstimateUserOpResult{}, fmt.Errorf("%w (simulation: %w)", err, simulationErr)
+	case err != nil:
+		log.Printf("gas (chain %d): bundler cannot estimate %s, using the simulation: %v", e.chainId, userOp.Sender, err)
+		return simulated, nil
+	case simulationErr != nil:
+		log.Printf("gas (chain %d): cannot simulate %s: %v", e.chainId, userOp.Sender, simulationErr)
+		return result, nil
+	}
+
+	fields := []struct {
+		name      string
+		estimate  **big.Int
+		simulated *big.Int
+	}{
+		{"preVerificationGas", &result.PreVerificationGas, simulated.PreVerificationGas},
+		{"verificationGasLimit", &result.VerificationGasLimit, simulated.VerificationGasLimit},
+		{"callGasLimit", &result.CallGasLimit, simulated.CallGasLimit},
+		{"paymasterVerificationGasLimit", &result.PaymasterVerificationGasLimit, simulated.PaymasterVerificationGasLimit},
+	}
+	for _, field := range fields {
+		if *field.estimate == nil || field.simulated == nil {
+			continue
+		}
+		if !within(*field.estimate, field.simulated, e.tolerance) {
+			log.Printf("gas (chain %d): %s of %s estimated at %s by the bundler, %s by simulation", e.chainId, field.name, userOp.Sender, *field.estimate, field.simulated)
+		}
+		if field.simulated.Cmp(*field.estimate) > 0 {
+			*field.estimate = field.simulated
+		}
+	}
+	return result, nil
+}
+
+// within reports whether a and b differ by at most tolerance percent of the
+// larger one.
+func within(a *big.Int, b *big.Int, tolerance uint64) bool {
+	larger, diff := new(big.Int).Set(a), new(big.Int).Sub(a, b)
+	if b.Cmp(a) > 0 {
+		larger.Set(b)
+	}
+	diff.Abs(diff).Mul(diff, big.NewInt(100))
+	return diff.Cmp(new(big.Int).Mul(larger, new(big.Int).SetUint64(tolerance))) <= 0
+}
@@ -0,0 +1,266 @@
+package gas
+
+import (
+	"fmt"
+	"math/big"
+	"web3-account-abstraction-api/internal/bundler"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultPercent is the markup of a field no rule configures.
+const DefaultPercent = 110
+
+// Markup raises an estimate to estimate*Percent/100 + Add, then clamps it to
+// [Min, Max]. Zero values are unset and inherited from the markup this one
+// overrides.
+type Markup struct {
+	// defaults to DefaultPercent
+	Percent uint64 `mapstructure:"percent"`
+	// gas, decimal
+	Add string `mapstructure:"add"`
+	Min string `mapstructure:"min"`
+	Max string `mapstructure:"max"`
+}
+
+func (m Markup) IsZero() bool {
+	return m == Markup{}
+}
+
+// Fields holds a markup per user operation gas field.
+type Fields struct {
+	PreVerificationGas            Markup `mapstructure:"pre_verification_gas"`
+	VerificationGasLimit          Markup `mapstructure:"verification_gas_limit"`
+	CallGasLimit                  Markup `mapstructure:"call_gas_limit"`
+	PaymasterVerificationGasLimit Markup `mapstructure:"paymaster_verification_gas_limit"`
+	PaymasterPostOpGasLimit       Markup `mapstructure:"paymaster_post_op_gas_limit"`
+}
+
+func (f Fields) all() []Markup {
+	return []Markup{
+		f.PreVerificationGas,
+		f.VerificationGasLimit,
+		f.CallGasLimit,
+		f.PaymasterVerificationGasLimit,
+		f.PaymasterPostOpGasLimit,
+	}
+}
+
+// Override replaces the markups of the fields it sets for operations calling
+// Target.
+type Override struct {
+	Target string `mapstructure:"target"`
+	Fields `mapstructure:",squash"`
+}
+
+// Config holds the gas markups of a chain. A field falls back to Default,
+// then to DefaultPercent.
+type Config struct {
+	Default   Markup `mapstructure:"default"`
+	Fields    `mapstructure:",squash"`
+	Overrides []Override `mapstructure:"overrides"`
+	// off (default), fallback or cross_check, see NewEstimator
+	Simulation string `mapstructure:"simulation"`
+	// cross_check: percent, defaults to DefaultCrossCheckTolerance
+	CrossCheckTolerance uint64 `mapstructure:"cross_check_tolerance"`
+}
+
+// Validate checks every markup of cfg and its simulation mode.
+func (cfg Config) Validate() error {
+	switch cfg.Simulation {
+	case "", SimulationOff, SimulationFallback, SimulationCrossCheck:
+	default:
+		return fmt.Errorf("unknown gas simulation mode %q", cfg.Simulation)
+	}
+
+	markups := append([]Markup{cfg.Default}, cfg.Fields.all()...)
+	for _, override := range cfg.Overrides {
+		if !common.IsHexAddress(override.Target) {
+			return fmt.Errorf("invalid gas override target %q", override.Target)
+		}
+		markups = append(markups, override.Fields.all()...)
+	}
+	for _, m := range markups {
+		_, err := parseRule(m)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rule is a parsed Markup.
+type rule struct {
+	percent  *big.Int
+	add      *big.Int
+	min, max *big.Int
+}
+
+func parseRule(m Markup) (*rule, error) {
+	if m.IsZero() {
+		return nil, nil
+	}
+	r := &rule{}
+	if m.Percent != 0 {
+		r.percent = new(big.Int).SetUint64(m.Percent)
+	}
+	values := []struct {
+		name  string
+		value string
+		dst   **big.Int
+	}{
+		{"add", m.Add, &r.add},
+		{"min", m.Min, &r.min},
+		{"max", m.Max, &r.max},
+	}
+	for _, v := range values {
+		if v.value == "" {
+			continue
+		}
+		value, ok := new(big.Int).SetString(v.value, 10)
+		if !ok || value.Sign() < 0 {
+			return nil, fmt.Errorf("invalid gas markup %s %q", v.name, v.value)
+		}
+		*v.dst = value
+	}
+	if r.min != nil && r.max != nil && r.min.Cmp(r.max) > 0 {
+		return nil, fmt.Errorf("gas markup min %s is above max %s", r.min, r.max)
+	}
+	return r, nil
+}
+
+// inherit fills the unset values of r from fallback.
+func (r *rule) inherit(fallback *rule) *rule {
+	if r == nil {
+		return fallback
+	}
+	if fallback == nil {
+		return r
+	}
+	result := *r
+	if result.percent == nil {
+		result.percent = fallback.percent
+	}
+	if result.add == nil {
+		result.add = fallback.add
+	}
+	if result.min == nil {
+		result.min = fallback.min
+	}
+	if result.max == nil {
+		result.max = fallback.max
+	}
+	return &result
+}
+
+func (r *rule) apply(estimate *big.Int) *big.Int {
+	percent := r.percent
+	if percent == nil {
+		percent = big.NewInt(DefaultPercent)
+	}
+	result := new(big.Int).Mul(estimate, percent)
+	result.Quo(result, big.NewInt(100))
+	if r.add != nil {
+		result.Add(result, r.add)
+	}
+	if r.min != nil && result.Cmp(r.min) < 0 {
+		result.Set(r.min)
+	}
+	if r.max != nil && result.Cmp(r.max) > 0 {
+		result.Set(r.max)
+	}
+	return result
+}
+
+// rules holds the parsed rule of every field, in Fields.all order.
+type rules [5]*rule
+
+func parseRules(f Fields, fallback rules) (rules, error) {
+	var result rules
+	for i, m := range f.all() {
+		r, err := parseRule(m)
+		if err != nil {
+			return rules{}, err
+		}
+		result[i] = r.inherit(fallback[i])
+	}
+	return result, nil
+}
+
+// Markups applies the gas markups of a chain to bundler estimates.
+type Markups struct {
+	rules     rules
+	overrides map[common.Address]rules
+}
+
+func New(cfg Config) (*Markups, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	fallback, err := parseRule(cfg.Default)
+	if err != nil {
+		return nil, err
+	}
+	if fallback == nil {
+		fallback = &rule{}
+	}
+	m := &Markups{overrides: map[common.Address]rules{}}
+	m.rules, err = parseRules(cfg.Fields, rules{fallback, fallback, fallback, fallback, fallback})
+	if err != nil {
+		return nil, err
+	}
+	for _, override := range cfg.Overrides {
+		target := common.HexToAddress(override.Target)
+		if _, ok := m.overrides[target]; ok {
+			return nil, fmt.Errorf("gas override target %s configured twice", target.Hex())
+		}
+		m.overrides[target], err = parseRules(override.Fields, m.rules)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Apply sets the gas fields of userOp from estimate, marked up. When userOp
+// calls several overridden targets the largest result wins. Fields the
+// bundler did not estimate keep the value userOp was estimated with.
+func (m *Markups) Apply(userOp *model.UserOperation, estimate bundler.EstimateUserOpResult, targets []common.Address) {
+	applicable := []rules{}
+	for _, target := range targets {
+		if r, ok := m.overrides[target]; ok {
+			applicable = append(applicable, r)
+		}
+	}
+	if len(applicable) == 0 {
+		applicable = append(applicable, m.rules)
+	}
+
+	fields := []struct {
+		estimate *big.Int
+		dst      **big.Int
+	}{
+		{estimate.PreVerificationGas, &userOp.PreVerificationGas},
+		{estimate.VerificationGasLimit, &userOp.VerificationGasLimit},
+		{estimate.CallGasLimit, &userOp.CallGasLimit},
+		{estimate.PaymasterVerificationGasLimit, &userOp.PaymasterVerificationGasLimit},
+		{estimate.PaymasterPostOpGasLimit, &userOp.PaymasterPostOpGasLimit},
+	}
+	for i, field := range fields {
+		// paymaster fields of an operation without a paymaster stay unset
+		if field.estimate == nil || *field.dst == nil {
+			continue
+		}
+		var result *big.Int
+		for _, r := range applicable {
+			value := r[i].apply(field.estimate)
+			if result == nil || value.Cmp(result) > 0 {
+				result = value
+			}
+		}
+		*field.dst = result
+	}
+}
@@ -0,0 +1,274 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"web3-account-abstraction-api/generated/abi/account"
+	"web3-account-abstraction-api/generated/abi/entrypoint"
+	"web3-account-abstraction-api/generated/abi/entrypointv06"
+	"web3-account-abstraction-api/generated/abi/paymaster"
+	"web3-account-abstraction-api/generated/abi/paymasterv06"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/bundler"
+	contract "web3-account-abstraction-api/internal/contracts"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/utils"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// overheads of the calldata based preVerificationGas, the reference
+// bundler's defaults for a bundle of one operation
+const (
+	fixedGas       = 21_000
+	perUserOpGas   = 18_300
+	perUserOpWord  = 4
+	zeroByteGas    = 4
+	nonZeroByteGas = 16
+)
+
+// binary searches stop once their bounds are this close
+const searchPrecision = 1_000
+
+var ErrSimulationReverted = apperr.New(apperr.KindChain, "simulation_reverted", "simulation reverted")
+
+// Simulator estimates user operation gas locally: each phase is run with
+// eth_call as the EntryPoint would call it, searching for the least gas it
+// succeeds with. An undeployed sender is given the code it will be created
+// with through a state override.
+type Simulator struct {
+	client    *ethclient.Client
+	contracts contract.Contracts
+}
+
+func NewSimulator(client *ethclient.Client, contracts contract.Contracts) *Simulator {
+	return &Simulator{
+		client:    client,
+		contracts: contracts,
+	}
+}
+
+// callMsg is the eth_call transaction object.
+type callMsg struct {
+	From common.Address `json:"from"`
+	To   common.Address `json:"to"`
+	Gas  hexutil.Uint64 `json:"gas"`
+	Data hexutil.Bytes  `json:"data"`
+}
+
+// overrideAccount is the part of an eth_call state override the simulation
+// uses.
+type overrideAccount struct {
+	Code hexutil.Bytes `json:"code"`
+}
+
+// EstimateUserOpGas estimates userOp, signed with model.DummySignature. The
+// paymaster postOp is not simulated, its limit is left nil.
+func (s *Simulator) EstimateUserOpGas(userOp model.UserOperation) (bundler.EstimateUserOpResult, error) {
+	ctx := context.Background()
+	userOp.Signature = model.DummySignature
+	entryPoint := s.contracts.EntryPointAddress
+
+	header, err := s.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return bundler.EstimateUserOpResult{}, err
+	}
+	overrides := map[common.Address]overrideAccount{}
+
+	verificationGas := big.NewInt(0)
+	code, err := s.client.CodeAt(ctx, userOp.Sender, nil)
+	if err != nil {
+		return bundler.EstimateUserOpResult{}, err
+	}
+	if len(code) == 0 {
+		if userOp.Factory == nil || utils.IsZeroAddress(*userOp.Factory) {
+			return bundler.EstimateUserOpResult{}, fmt.Errorf("sender %s is not deployed and has no factory", userOp.Sender)
+		}
+		deployment, err := s.client.EstimateGas(ctx, ethereum.CallMsg{
+			From: entryPoint,
+			To:   userOp.Factory,
+			Data: userOp.FactoryData,
+		})
+		if err != nil {
+			return bundler.EstimateUserOpResult{}, fmt.Errorf("deployment: %w", err)
+		}
+		verificationGas.SetUint64(deployment - intrinsicGas(userOp.FactoryData))
+
+		code, err = s.contracts.GetAccountRuntimeCode(userOp.FactoryData)
+		if err != nil {
+			return bundler.EstimateUserOpResult{}, err
+		}
+		overrides[userOp.Sender] = overrideAccount{Code: code}
+	}
+
+	hash := userOp.Hash(entryPoint, s.contracts.ChainId())
+	validateData, err := validateUserOpData(userOp, hash)
+	if err != nil {
+		return bundler.EstimateUserOpResult{}, err
+	}
+	validationGas, err := s.search(ctx, entryPoint, userOp.Sender, validateData, overrides, header.GasLimit)
+	if err != nil {
+		return bundler.EstimateUserOpResult{}, fmt.Errorf("account validation: %w", err)
+	}
+	verificationGas.Add(verificationGas, validationGas)
+
+	callGas := big.NewInt(0)
+	if len(userOp.CallData) > 0 {
+		callGas, err = s.search(ctx, entryPoint, userOp.Sender, userOp.CallData, overrides, header.GasLimit)
+		if err != nil {
+			return bundler.EstimateUserOpResult{}, fmt.Errorf("execution: %w", err)
+		}
+	}
+
+	preVerificationGas, err := callDataPreVerificationGas(userOp)
+	if err != nil {
+		return bundler.EstimateUserOpResult{}, err
+	}
+	result := bundler.EstimateUserOpResult{
+		PreVerificationGas:   preVerificationGas,
+		CallGasLimit:         callGas,
+		VerificationGasLimit: verificationGas,
+	}
+
+	if userOp.Paymaster == nil || utils.IsZeroAddress(*userOp.Paymaster) {
+		return result, nil
+	}
+	paymasterData, err := validatePaymasterUserOpData(userOp, hash)
+	if err != nil {
+		return bundler.EstimateUserOpResult{}, err
+	}
+	paymasterGas, err := s.search(ctx, entryPoint, *userOp.Paymaster, paymasterData, overrides, header.GasLimit)
+	if err != nil {
+		return bundler.EstimateUserOpResult{}, fmt.Errorf("paymaster validation: %w", err)
+	}
+	if userOp.Version == model.EntryPointV06 {
+		// v0.6 limits the paymaster's validation by verificationGasLimit too
+		if paymasterGas.Cmp(result.VerificationGasLimit) > 0 {
+			result.VerificationGasLimit = paymasterGas
+		}
+	} else {
+		result.PaymasterVerificationGasLimit = paymasterGas
+	}
+	return result, nil
+}
+
+// search returns the least gas the call from -> to succeeds with, within
+// searchPrecision, less its intrinsic cost: the gas the EntryPoint has to
+// forward to it. Only a call failing with hi, the block gas limit, is a
+// revert: below it any failure is taken for too little gas, as an inner call
+// running out of gas is rethrown by Account.execute as a plain revert.
+func (s *Simulator) search(ctx context.Context, from common.Address, to common.Address, data []byte, overrides map[common.Address]overrideAccount, hi uint64) (*big.Int, error) {
+	call := func(gas uint64) error {
+		var result hexutil.Bytes
+		msg := callMsg{From: from, To: to, Gas: hexutil.Uint64(gas), Data: data}
+		return s.client.Client().CallContext(ctx, &result, "eth_call", msg, "latest", overrides)
+	}
+
+	var rpcErr rpc.Error
+	err := call(hi)
+	if errors.As(err, &rpcErr) {
+		return nil, fmt.Errorf("%w: %w", ErrSimulationReverted, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	intrinsic := intrinsicGas(data)
+	lo := intrinsic
+	for hi > lo && hi-lo > searchPrecision {
+		mid := lo + (hi-lo)/2
+		err = call(mid)
+		if err == nil {
+			hi = mid
+			continue
+		}
+		// the node could not run the call at all
+		if !errors.As(err, &rpcErr) {
+			return nil, err
+		}
+		lo = mid
+	}
+	return new(big.Int).SetUint64(hi - intrinsic), nil
+}
+
+func validateUserOpData(userOp model.UserOperation, hash common.Hash) ([]byte, error) {
+	if userOp.Version != model.EntryPointV06 {
+		accountAbi, _ := account.AccountMetaData.GetAbi()
+		return accountAbi.Pack("validateUserOp", userOp.Pack(), hash, big.NewInt(0))
+	}
+
+	// the v0.6 account interface takes the unpacked operation, typed as
+	// the v0.6 EntryPoint takes it
+	entryPointAbi, _ := entrypointv06.EntryPointV06MetaData.GetAbi()
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	method := abi.NewMethod("validateUserOp", "validateUserOp", abi.Function, "nonpayable", false, false,
+		abi.Arguments{
+			{Name: "userOp", Type: entryPointAbi.Methods["simulateValidation"].Inputs[0].Type},
+			{Name: "userOpHash", Type: bytes32Type},
+			{Name: "missingAccountFunds", Type: uint256Type},
+		},
+		abi.Arguments{{Name: "validationData", Type: uint256Type}},
+	)
+	arguments, err := method.Inputs.Pack(userOp.PackV06(), hash, big.NewInt(0))
+	if err != nil {
+		return nil, err
+	}
+	return append(method.ID, arguments...), nil
+}
+
+func validatePaymasterUserOpData(userOp model.UserOperation, hash common.Hash) ([]byte, error) {
+	if userOp.Version == model.EntryPointV06 {
+		paymasterAbi, _ := paymasterv06.PaymasterV06MetaData.GetAbi()
+		return paymasterAbi.Pack("validatePaymasterUserOp", userOp.PackV06(), hash, userOp.MaxGasCost())
+	}
+	paymasterAbi, _ := paymaster.PaymasterMetaData.GetAbi()
+	return paymasterAbi.Pack("validatePaymasterUserOp", userOp.Pack(), hash, userOp.MaxGasCost())
+}
+
+// callDataPreVerificationGas prices the calldata userOp adds to the bundle
+// transaction plus its share of the bundle overhead.
+func callDataPreVerificationGas(userOp model.UserOperation) (*big.Int, error) {
+	var encoded []byte
+	var err error
+	if userOp.Version == model.EntryPointV06 {
+		entryPointAbi, _ := entrypointv06.EntryPointV06MetaData.GetAbi()
+		encoded, err = entryPointAbi.Methods["getUserOpHash"].Inputs.Pack(userOp.PackV06())
+	} else {
+		entryPointAbi, _ := entrypoint.EntryPointMetaData.GetAbi()
+		encoded, err = entryPointAbi.Methods["getUserOpHash"].Inputs.Pack(userOp.Pack())
+	}
+	if err != nil {
+		return nil, err
+	}
+	// drop the offset of the dynamic tuple, leaving the operation itself
+	encoded = encoded[32:]
+
+	gas := uint64(fixedGas + perUserOpGas + perUserOpWord*((len(encoded)+31)/32))
+	gas += callDataGas(encoded)
+	return new(big.Int).SetUint64(gas), nil
+}
+
+func callDataGas(data []byte) uint64 {
+	var gas uint64
+	for _, b := range data {
+		if b == 0 {
+			gas += zeroByteGas
+		} else {
+			gas += nonZeroByteGas
+		}
+	}
+	return gas
+}
+
+// intrinsicGas is what a transaction carrying data costs before it runs.
+func intrinsicGas(data []byte) uint64 {
+	return fixedGas + callDataGas(data)
+}
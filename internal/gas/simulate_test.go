@@ -0,0 +1,100 @@
+package gas
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakeEth answers eth_call like a node running a call that needs need gas:
+// it succeeds with at least need and fails with failure below it. revert
+// makes it fail with any gas.
+type fakeEth struct {
+	need    uint64
+	failure string
+	revert  bool
+	calls   int
+}
+
+func (f *fakeEth) Call(msg callMsg, block string, overrides map[common.Address]overrideAccount) (hexutil.Bytes, error) {
+	f.calls++
+	if f.revert || uint64(msg.Gas) < f.need {
+		return nil, errors.New(f.failure)
+	}
+	return hexutil.Bytes{}, nil
+}
+
+func newTestSimulator(t *testing.T, eth *fakeEth) *Simulator {
+	t.Helper()
+	server := rpc.NewServer()
+	err := server.RegisterName("eth", eth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return &Simulator{client: ethclient.NewClient(client)}
+}
+
+func TestSearch(t *testing.T) {
+	const blockGasLimit = 30_000_000
+	data := []byte{0xb6, 0x1d, 0x27, 0xf6}
+
+	tests := []struct {
+		name string
+		eth  fakeEth
+		// 0 when the search must fail with ErrSimulationReverted
+		want uint64
+	}{
+		{"out of gas", fakeEth{need: 150_000, failure: "out of gas"}, 150_000},
+		{"inner call out of gas, rethrown as a revert", fakeEth{need: 150_000, failure: "execution reverted"}, 150_000},
+		{"custom error below the limit", fakeEth{need: 2_000_000, failure: "execution reverted: 0x8baa579f"}, 2_000_000},
+		{"succeeds with the least gas", fakeEth{need: 0, failure: "out of gas"}, intrinsicGas(data)},
+		{"reverts with the block gas limit", fakeEth{failure: "execution reverted", revert: true}, 0},
+	}
+	for _, tt := range tests {
+		eth := tt.eth
+		s := newTestSimulator(t, &eth)
+		got, err := s.search(context.Background(), common.HexToAddress("0x01"), common.HexToAddress("0x02"), data, nil, blockGasLimit)
+		if tt.want == 0 {
+			if !errors.Is(err, ErrSimulationReverted) {
+				t.Errorf("%s: got %v, %v, want ErrSimulationReverted", tt.name, got, err)
+			}
+			if eth.calls != 1 {
+				t.Errorf("%s: %d calls, want only the one with the block gas limit", tt.name, eth.calls)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+
+		// the search returns the gas forwarded to the call, without the
+		// intrinsic cost, at most searchPrecision above what it needs
+		want := tt.want - intrinsicGas(data)
+		if got.Uint64() < want || got.Uint64() > want+searchPrecision {
+			t.Errorf("%s: got %d, want %d to %d", tt.name, got, want, want+searchPrecision)
+		}
+	}
+}
+
+func TestSearchNodeUnreachable(t *testing.T) {
+	eth := fakeEth{need: 150_000, failure: "out of gas"}
+	s := newTestSimulator(t, &eth)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.search(ctx, common.HexToAddress("0x01"), common.HexToAddress("0x02"), nil, nil, 30_000_000)
+	if err == nil || errors.Is(err, ErrSimulationReverted) {
+		t.Fatalf("got %v, want the node's error, not a revert", err)
+	}
+}
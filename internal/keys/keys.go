@@ -0,0 +1,119 @@
+package keys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MasterKey wraps the per-key data keys (envelope encryption): every private
+// key is encrypted with its own random data key, and only the data key is
+// encrypted with the master key.
+type MasterKey struct {
+	aead cipher.AEAD
+}
+
+// NewMasterKey takes a hex encoded 32 byte AES-256 key.
+func NewMasterKey(hexKey string) (*MasterKey, error) {
+	key := common.FromHex(hexKey)
+	if len(key) != 32 {
+		return nil, fmt.Errorf("master key must be 32 bytes, got %d", len(key))
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &MasterKey{aead: aead}, nil
+}
+
+// Generate creates a private key and returns it with its sealed form.
+func (m *MasterKey) Generate() (*ecdsa.PrivateKey, model.EncryptedKey, error) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, model.EncryptedKey{}, err
+	}
+
+	sealed, err := m.Seal(privateKey)
+	if err != nil {
+		return nil, model.EncryptedKey{}, err
+	}
+	return privateKey, sealed, nil
+}
+
+func (m *MasterKey) Seal(privateKey *ecdsa.PrivateKey) (model.EncryptedKey, error) {
+	dataKey := make([]byte, 32)
+	_, err := io.ReadFull(rand.Reader, dataKey)
+	if err != nil {
+		return model.EncryptedKey{}, err
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return model.EncryptedKey{}, err
+	}
+	ciphertext, err := seal(dataAEAD, crypto.FromECDSA(privateKey))
+	if err != nil {
+		return model.EncryptedKey{}, err
+	}
+	wrappedDataKey, err := seal(m.aead, dataKey)
+	if err != nil {
+		return model.EncryptedKey{}, err
+	}
+
+	return model.EncryptedKey{
+		Ciphertext: ciphertext,
+		DataKey:    wrappedDataKey,
+	}, nil
+}
+
+func (m *MasterKey) Open(key model.EncryptedKey) (*ecdsa.PrivateKey, error) {
+	dataKey, err := open(m.aead, key.DataKey)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key: %w", err)
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(dataAEAD, key.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting key: %w", err)
+	}
+	return crypto.ToECDSA(plaintext)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal prefixes the ciphertext with its random nonce.
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	_, err := io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, nil)
+}
@@ -0,0 +1,13 @@
+package model
+
+import "math/big"
+
+// DepositInfo is an account's deposit and stake in the EntryPoint.
+type DepositInfo struct {
+	Address         Address  `json:"address"`
+	Deposit         *big.Int `json:"deposit"`
+	Staked          bool     `json:"staked"`
+	Stake           *big.Int `json:"stake"`
+	UnstakeDelaySec uint32   `json:"unstakeDelaySec"`
+	WithdrawTime    *big.Int `json:"withdrawTime"`
+}
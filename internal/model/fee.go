@@ -0,0 +1,36 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+)
+
+type FeeTier string
+
+const (
+	FeeTierSlow   FeeTier = "slow"
+	FeeTierNormal FeeTier = "normal"
+	FeeTierFast   FeeTier = "fast"
+)
+
+// ParseFeeTier accepts slow, normal and fast; empty means normal.
+func ParseFeeTier(s string) (FeeTier, error) {
+	switch FeeTier(s) {
+	case "", FeeTierNormal:
+		return FeeTierNormal, nil
+	case FeeTierSlow, FeeTierFast:
+		return FeeTier(s), nil
+	default:
+		return "", fmt.Errorf("unknown fee tier %q", s)
+	}
+}
+
+// Fees are the gas fees chosen for an operation and how they were chosen.
+type Fees struct {
+	Tier     FeeTier `json:"tier"`
+	Strategy string  `json:"strategy"`
+	// base fee the fees were derived from, nil when the strategy ignores it
+	BaseFee              *big.Int `json:"baseFee,omitempty"`
+	MaxFeePerGas         *big.Int `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int `json:"maxPriorityFeePerGas"`
+}
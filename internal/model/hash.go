@@ -0,0 +1,69 @@
+package model
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Hash is the EntryPoint v0.7 userOpHash, equal to EntryPoint.getUserOpHash:
+// keccak256(abi.encode(keccak256(encode(userOp)), entryPoint, chainId)).
+func (p *PackedUserOperation) Hash(entryPoint Address, chainId *big.Int) common.Hash {
+	inner := crypto.Keccak256(
+		common.LeftPadBytes(p.Sender.Bytes(), 32),
+		word(p.Nonce),
+		crypto.Keccak256(p.InitCode),
+		crypto.Keccak256(p.CallData),
+		p.AccountGasLimits[:],
+		word(p.PreVerificationGas),
+		p.GasFees[:],
+		crypto.Keccak256(p.PaymasterAndData),
+	)
+
+	return crypto.Keccak256Hash(
+		inner,
+		common.LeftPadBytes(entryPoint.Bytes(), 32),
+		word(chainId),
+	)
+}
+
+// Hash is the EntryPoint v0.6 userOpHash, equal to EntryPoint.getUserOpHash.
+func (p *UserOperationV06) Hash(entryPoint Address, chainId *big.Int) common.Hash {
+	inner := crypto.Keccak256(
+		common.LeftPadBytes(p.Sender.Bytes(), 32),
+		word(p.Nonce),
+		crypto.Keccak256(p.InitCode),
+		crypto.Keccak256(p.CallData),
+		word(p.CallGasLimit),
+		word(p.VerificationGasLimit),
+		word(p.PreVerificationGas),
+		word(p.MaxFeePerGas),
+		word(p.MaxPriorityFeePerGas),
+		crypto.Keccak256(p.PaymasterAndData),
+	)
+
+	return crypto.Keccak256Hash(
+		inner,
+		common.LeftPadBytes(entryPoint.Bytes(), 32),
+		word(chainId),
+	)
+}
+
+// Hash returns the userOpHash for the operation's entry point version.
+func (u *UserOperation) Hash(entryPoint Address, chainId *big.Int) common.Hash {
+	if u.Version == EntryPointV06 {
+		packed := u.PackV06()
+		return packed.Hash(entryPoint, chainId)
+	}
+	packed := u.Pack()
+	return packed.Hash(entryPoint, chainId)
+}
+
+// word abi encodes a uint256, treating nil as zero.
+func word(value *big.Int) []byte {
+	if value == nil {
+		return make([]byte, 32)
+	}
+	return common.LeftPadBytes(value.Bytes(), 32)
+}
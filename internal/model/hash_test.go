@@ -0,0 +1,105 @@
+package model
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+	"web3-account-abstraction-api/generated/abi/entrypoint"
+	"web3-account-abstraction-api/generated/abi/entrypointv06"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// the canonical deployments, the same address on every chain
+var (
+	entryPointV07 = common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+	entryPointV06 = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+)
+
+func testOperation(version EntryPointVersion, deployed bool, paymaster bool) UserOperation {
+	userOp := UserOperation{
+		Version:              version,
+		Sender:               common.HexToAddress("0x1306b01bC3e4AD202612D3843387e94737673F53"),
+		Nonce:                big.NewInt(7),
+		CallData:             common.FromHex("0xb61d27f6000000000000000000000000d8da6bf26964af9d7eed9e03e53415d37aa960450000000000000000000000000000000000000000000000000de0b6b3a764000000000000000000000000000000000000000000000000000000000000000000600000000000000000000000000000000000000000000000000000000000000000"),
+		CallGasLimit:         big.NewInt(100_000),
+		VerificationGasLimit: big.NewInt(200_000),
+		PreVerificationGas:   big.NewInt(50_000),
+		MaxFeePerGas:         big.NewInt(2_000_000_000),
+		MaxPriorityFeePerGas: big.NewInt(1_000_000_000),
+		Signature:            DummySignature,
+	}
+	if !deployed {
+		factory := common.HexToAddress("0x9406Cc6185a346906296840746125a0E44976454")
+		userOp.Factory = &factory
+		userOp.FactoryData = common.FromHex("0x5fbfb9cf000000000000000000000000a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b20000000000000000000000000000000000000000000000000000000000000001")
+	}
+	if paymaster {
+		address := common.HexToAddress("0x0000000000325602a77416A16136FDafd04b299f")
+		userOp.Paymaster = &address
+		userOp.PaymasterVerificationGasLimit = big.NewInt(300_000)
+		userOp.PaymasterPostOpGasLimit = big.NewInt(50_000)
+		userOp.PaymasterData = common.FromHex("0x000000000000000000000000000000000000000000000000000000006553f100000000000000000000000000000000000000000000000000000000000000000000")
+	}
+	return userOp
+}
+
+// TestUserOperationHash checks the offline userOpHash against
+// EntryPoint.getUserOpHash, called on the canonical v0.7 and v0.6 EntryPoints
+// of the chain HASH_TEST_RPC_URL points to.
+func TestUserOperationHash(t *testing.T) {
+	url := os.Getenv("HASH_TEST_RPC_URL")
+	if url == "" {
+		t.Skip("HASH_TEST_RPC_URL is not set")
+	}
+	client, err := ethclient.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entryPoint, err := entrypoint.NewEntryPoint(entryPointV07, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entryPointV06Contract, err := entrypointv06.NewEntryPointV06(entryPointV06, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &bind.CallOpts{Context: context.Background()}
+
+	tests := []struct {
+		name   string
+		userOp UserOperation
+	}{
+		{"v0.7", testOperation(EntryPointV07, true, false)},
+		{"v0.7 deploying, sponsored", testOperation(EntryPointV07, false, true)},
+		{"v0.6", testOperation(EntryPointV06, true, false)},
+		{"v0.6 deploying, sponsored", testOperation(EntryPointV06, false, true)},
+	}
+	for _, tt := range tests {
+		var want [32]byte
+		var got common.Hash
+		if tt.userOp.Version == EntryPointV06 {
+			want, err = entryPointV06Contract.GetUserOpHash(opts, entrypointv06.UserOperation(tt.userOp.PackV06()))
+			got = tt.userOp.Hash(entryPointV06, chainId)
+		} else {
+			want, err = entryPoint.GetUserOpHash(opts, entrypoint.PackedUserOperation(tt.userOp.Pack()))
+			got = tt.userOp.Hash(entryPointV07, chainId)
+		}
+		if err != nil {
+			t.Errorf("%s: getUserOpHash: %v", tt.name, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: got %s, EntryPoint returns %s", tt.name, got, common.Hash(want))
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"math/big"
+	"time"
+)
+
+// SponsorshipPolicy restricts which user operations the paymaster signs for.
+// Nil limits and empty lists are not enforced.
+type SponsorshipPolicy struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+
+	// gas limits are in wei of maximum gas cost; budgets and caps count the
+	// spend of each chain separately
+	MaxGasCostPerOp      *big.Int `json:"maxGasCostPerOp"`
+	WalletDailyGasBudget *big.Int `json:"walletDailyGasBudget"`
+	GlobalSpendCap       *big.Int `json:"globalSpendCap"`
+
+	AllowedTargets   []Address `json:"allowedTargets"`
+	AllowedSelectors []string  `json:"allowedSelectors"`
+	// operations making no call only deploy the wallet and pass no
+	// allowlist; a policy with allowlists sponsors them only when set
+	AllowDeploys bool `json:"allowDeploys"`
+
+	ActiveFrom  *time.Time `json:"activeFrom"`
+	ActiveUntil *time.Time `json:"activeUntil"`
+
+	// how long the paymaster signature stays valid, in seconds
+	ValiditySeconds int64 `json:"validitySeconds"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -1,16 +1,27 @@
 package model
 
 import (
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"web3-account-abstraction-api/utils"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 type Address = common.Address
 
+// DummySignature has the shape of an ECDSA signature without being valid for
+// anything, so validation can be estimated before the real one exists.
+var DummySignature = common.FromHex("0xfffffffffffffffffffffffffffffff0000000000000000000000000000000007aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1c")
+
+// UserOperation is kept in the unpacked v0.7 shape for every entry point
+// version; Version selects how it is packed, hashed and sent to the bundler.
+// The zero value of Version means v0.7.
 type UserOperation struct {
+	Version                       EntryPointVersion
 	Sender                        Address
 	Nonce                         *big.Int
 	Factory                       *Address
@@ -36,7 +47,7 @@ func (u *UserOperation) Pack() PackedUserOperation {
 	return PackedUserOperation{
 		Sender:             u.Sender,
 		Nonce:              u.Nonce,
-		InitCode:           append(u.Factory[:], u.FactoryData...),
+		InitCode:           u.packInitCode(),
 		CallData:           u.CallData,
 		AccountGasLimits:   accountGasLimit,
 		PreVerificationGas: u.PreVerificationGas,
@@ -46,6 +57,15 @@ func (u *UserOperation) Pack() PackedUserOperation {
 	}
 }
 
+// packInitCode leaves initCode empty for deployed accounts, matching what the
+// bundler packs from an operation without factory.
+func (u *UserOperation) packInitCode() []byte {
+	if u.Factory == nil || utils.IsZeroAddress(*u.Factory) {
+		return []byte{}
+	}
+	return append(u.Factory.Bytes(), u.FactoryData...)
+}
+
 func (u *UserOperation) packAccountGasLimit(
 	verificationGasLimit *big.Int,
 	callGasLimit *big.Int) [32]byte {
@@ -57,7 +77,7 @@ func (u *UserOperation) packAccountGasLimit(
 }
 
 func (u *UserOperation) packPaymasterAndPaymasterData() []byte {
-	if u.Paymaster == nil || utils.IsZeroAddress(u.Paymaster) {
+	if u.Paymaster == nil || utils.IsZeroAddress(*u.Paymaster) {
 		return []byte{}
 	}
 
@@ -92,6 +112,43 @@ func (u *UserOperation) EncodePaymasterData(validUntil int, validAfter int, sign
 	return nil
 }
 
+// PackV06 converts to the v0.6 layout, which has no separate paymaster gas
+// limits.
+func (u *UserOperation) PackV06() UserOperationV06 {
+	paymasterAndData := []byte{}
+	if u.Paymaster != nil && !utils.IsZeroAddress(*u.Paymaster) {
+		paymasterAndData = append(u.Paymaster.Bytes(), u.PaymasterData...)
+	}
+
+	return UserOperationV06{
+		Sender:               u.Sender,
+		Nonce:                u.Nonce,
+		InitCode:             u.packInitCode(),
+		CallData:             u.CallData,
+		CallGasLimit:         u.CallGasLimit,
+		VerificationGasLimit: u.VerificationGasLimit,
+		PreVerificationGas:   u.PreVerificationGas,
+		MaxFeePerGas:         u.MaxFeePerGas,
+		MaxPriorityFeePerGas: u.MaxPriorityFeePerGas,
+		PaymasterAndData:     paymasterAndData,
+		Signature:            u.Signature,
+	}
+}
+
+type UserOperationV06 struct {
+	Sender               Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
 type PackedUserOperation struct {
 	Sender             Address
 	Nonce              *big.Int
@@ -103,3 +160,134 @@ type PackedUserOperation struct {
 	PaymasterAndData   []byte
 	Signature          []byte
 }
+
+type userOperationJSON struct {
+	Version                       EntryPointVersion `json:"entryPointVersion,omitempty"`
+	Sender                        Address           `json:"sender"`
+	Nonce                         *hexutil.Big      `json:"nonce"`
+	Factory                       *Address          `json:"factory,omitempty"`
+	FactoryData                   hexutil.Bytes     `json:"factoryData,omitempty"`
+	CallData                      hexutil.Bytes     `json:"callData"`
+	CallGasLimit                  *hexutil.Big      `json:"callGasLimit"`
+	VerificationGasLimit          *hexutil.Big      `json:"verificationGasLimit"`
+	PreVerificationGas            *hexutil.Big      `json:"preVerificationGas"`
+	MaxFeePerGas                  *hexutil.Big      `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas          *hexutil.Big      `json:"maxPriorityFeePerGas"`
+	Paymaster                     *Address          `json:"paymaster,omitempty"`
+	PaymasterVerificationGasLimit *hexutil.Big      `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       *hexutil.Big      `json:"paymasterPostOpGasLimit,omitempty"`
+	PaymasterData                 hexutil.Bytes     `json:"paymasterData,omitempty"`
+	Signature                     hexutil.Bytes     `json:"signature"`
+}
+
+// MarshalJSON encodes the operation in the hex format used by the bundler RPC.
+func (u UserOperation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(userOperationJSON{
+		Version:                       u.Version,
+		Sender:                        u.Sender,
+		Nonce:                         (*hexutil.Big)(u.Nonce),
+		Factory:                       u.Factory,
+		FactoryData:                   u.FactoryData,
+		CallData:                      u.CallData,
+		CallGasLimit:                  (*hexutil.Big)(u.CallGasLimit),
+		VerificationGasLimit:          (*hexutil.Big)(u.VerificationGasLimit),
+		PreVerificationGas:            (*hexutil.Big)(u.PreVerificationGas),
+		MaxFeePerGas:                  (*hexutil.Big)(u.MaxFeePerGas),
+		MaxPriorityFeePerGas:          (*hexutil.Big)(u.MaxPriorityFeePerGas),
+		Paymaster:                     u.Paymaster,
+		PaymasterVerificationGasLimit: (*hexutil.Big)(u.PaymasterVerificationGasLimit),
+		PaymasterPostOpGasLimit:       (*hexutil.Big)(u.PaymasterPostOpGasLimit),
+		PaymasterData:                 u.PaymasterData,
+		Signature:                     u.Signature,
+	})
+}
+
+func (u *UserOperation) UnmarshalJSON(data []byte) error {
+	var dec userOperationJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	*u = UserOperation{
+		Version:                       dec.Version,
+		Sender:                        dec.Sender,
+		Nonce:                         dec.Nonce.ToInt(),
+		Factory:                       dec.Factory,
+		FactoryData:                   dec.FactoryData,
+		CallData:                      dec.CallData,
+		CallGasLimit:                  dec.CallGasLimit.ToInt(),
+		VerificationGasLimit:          dec.VerificationGasLimit.ToInt(),
+		PreVerificationGas:            dec.PreVerificationGas.ToInt(),
+		MaxFeePerGas:                  dec.MaxFeePerGas.ToInt(),
+		MaxPriorityFeePerGas:          dec.MaxPriorityFeePerGas.ToInt(),
+		Paymaster:                     dec.Paymaster,
+		PaymasterVerificationGasLimit: dec.PaymasterVerificationGasLimit.ToInt(),
+		PaymasterPostOpGasLimit:       dec.PaymasterPostOpGasLimit.ToInt(),
+		PaymasterData:                 dec.PaymasterData,
+		Signature:                     dec.Signature,
+	}
+	return nil
+}
+
+// Validate checks that an operation received from a client has every field
+// its entry point version packs, each within the bits it is packed into.
+func (u *UserOperation) Validate() error {
+	type field struct {
+		name  string
+		value *big.Int
+		bits  int
+	}
+	fields := []field{
+		{"nonce", u.Nonce, 256},
+		{"callGasLimit", u.CallGasLimit, 128},
+		{"verificationGasLimit", u.VerificationGasLimit, 128},
+		{"preVerificationGas", u.PreVerificationGas, 256},
+		{"maxFeePerGas", u.MaxFeePerGas, 128},
+		{"maxPriorityFeePerGas", u.MaxPriorityFeePerGas, 128},
+	}
+	if u.Version != EntryPointV06 && u.Paymaster != nil && !utils.IsZeroAddress(*u.Paymaster) {
+		fields = append(fields,
+			field{"paymasterVerificationGasLimit", u.PaymasterVerificationGasLimit, 128},
+			field{"paymasterPostOpGasLimit", u.PaymasterPostOpGasLimit, 128},
+		)
+	}
+
+	for _, field := range fields {
+		if field.value == nil {
+			return fmt.Errorf("%s is required", field.name)
+		}
+		if field.value.Sign() < 0 || field.value.BitLen() > field.bits {
+			return fmt.Errorf("%s does not fit in uint%d", field.name, field.bits)
+		}
+	}
+	return nil
+}
+
+// MaxGasCost is the most the operation can be charged: every gas limit at
+// maxFeePerGas. In v0.6 the verification gas limit also bounds paymaster
+// validation and postOp, so it counts three times with a paymaster.
+func (u *UserOperation) MaxGasCost() *big.Int {
+	limits := []*big.Int{
+		u.CallGasLimit,
+		u.VerificationGasLimit,
+		u.PreVerificationGas,
+	}
+	hasPaymaster := u.Paymaster != nil && !utils.IsZeroAddress(*u.Paymaster)
+	switch {
+	case hasPaymaster && u.Version == EntryPointV06:
+		limits = append(limits, u.VerificationGasLimit, u.VerificationGasLimit)
+	case hasPaymaster:
+		limits = append(limits, u.PaymasterVerificationGasLimit, u.PaymasterPostOpGasLimit)
+	}
+
+	gas := new(big.Int)
+	for _, limit := range limits {
+		if limit != nil {
+			gas.Add(gas, limit)
+		}
+	}
+	if u.MaxFeePerGas == nil {
+		return gas.SetInt64(0)
+	}
+	return gas.Mul(gas, u.MaxFeePerGas)
+}
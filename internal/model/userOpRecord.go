@@ -0,0 +1,35 @@
+package model
+
+import (
+	"math/big"
+	"time"
+)
+
+type UserOperationStatus string
+
+const (
+	UserOperationStatusPending  UserOperationStatus = "pending"
+	UserOperationStatusIncluded UserOperationStatus = "included"
+	UserOperationStatusFailed   UserOperationStatus = "failed"
+	UserOperationStatusDropped  UserOperationStatus = "dropped"
+)
+
+// UserOperationRecord is a submitted user operation and what the reconciler
+// has learned about it since.
+type UserOperationRecord struct {
+	Hash          string              `json:"hash"`
+	ChainID       int64               `json:"chainId"`
+	Wallet        string              `json:"wallet"`
+	UserOperation UserOperation       `json:"userOperation"`
+	Status        UserOperationStatus `json:"status"`
+	SubmittedAt   time.Time           `json:"submittedAt"`
+	UpdatedAt     time.Time           `json:"updatedAt"`
+	TxHash        *string             `json:"txHash"`
+	ActualGasCost *big.Int            `json:"actualGasCost"`
+	ActualGasUsed *big.Int            `json:"actualGasUsed"`
+	Reason        *string             `json:"reason"`
+	// paymaster token taken from the wallet, only for token paid operations
+	ActualTokenCost *big.Int `json:"actualTokenCost,omitempty"`
+	// how the service chose the fees, nil when the client did
+	Fees *Fees `json:"fees,omitempty"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import "fmt"
+
+type EntryPointVersion string
+
+const (
+	EntryPointV06 EntryPointVersion = "v0.6"
+	EntryPointV07 EntryPointVersion = "v0.7"
+)
+
+// ParseEntryPointVersion accepts "v0.6"/"0.6" and "v0.7"/"0.7"; empty means
+// v0.7.
+func ParseEntryPointVersion(s string) (EntryPointVersion, error) {
+	switch s {
+	case "", "v0.7", "0.7":
+		return EntryPointV07, nil
+	case "v0.6", "0.6":
+		return EntryPointV06, nil
+	default:
+		return "", fmt.Errorf("unsupported entry point version %q", s)
+	}
+}
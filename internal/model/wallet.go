@@ -1,5 +1,33 @@
 package model
 
+import "math/big"
+
+type WalletStatus string
+
+const (
+	// WalletStatusReserved wallets have a salt and address but their
+	// deployment was not accepted by the bundler yet.
+	WalletStatusReserved WalletStatus = "reserved"
+	// WalletStatusUndeployed wallets are counterfactual: registered from
+	// their predicted address and deployed by their first operation.
+	WalletStatusUndeployed WalletStatus = "undeployed"
+	WalletStatusCreated    WalletStatus = "created"
+)
+
+// EncryptedKey is a private key encrypted with its own data key, itself
+// encrypted with the master key. Both are prefixed with their nonce.
+type EncryptedKey struct {
+	Ciphertext []byte
+	DataKey    []byte
+}
+
 type UserWallet struct {
-	Sender string
+	ChainID int64
+	Sender  string
+	// nil for wallets recorded before salts were stored
+	Salt   *big.Int
+	Owner  string
+	Status WalletStatus
+	// nil when the wallet is owned by the configured PRIVATE_KEY
+	OwnerKey *EncryptedKey `json:"-"`
 }
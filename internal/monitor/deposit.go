@@ -0,0 +1,142 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"time"
+	"web3-account-abstraction-api/internal/model"
+)
+
+const (
+	defaultInterval = 1 * time.Minute
+	webhookTimeout  = 10 * time.Second
+)
+
+// DepositReader reads the paymaster's EntryPoint deposit.
+type DepositReader interface {
+	GetPaymasterDepositInfo() (model.DepositInfo, error)
+}
+
+// LowDepositAlert is the JSON body POSTed to the webhook.
+type LowDepositAlert struct {
+	ChainID   int64         `json:"chainId"`
+	Paymaster model.Address `json:"paymaster"`
+	Deposit   *big.Int      `json:"deposit"`
+	Threshold *big.Int      `json:"threshold"`
+	Time      time.Time     `json:"time"`
+}
+
+// DepositMonitor polls the paymaster's deposit and alerts once each time it
+// drops below the threshold.
+type DepositMonitor struct {
+	chainId    int64
+	reader     DepositReader
+	threshold  *big.Int
+	interval   time.Duration
+	webhookURL string
+	client     *http.Client
+
+	// set while the deposit is below the threshold, so an alert is not
+	// repeated on every poll
+	low bool
+}
+
+func NewDepositMonitor(chainId int64, reader DepositReader, threshold *big.Int, interval time.Duration, webhookURL string) *DepositMonitor {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &DepositMonitor{
+		chainId:    chainId,
+		reader:     reader,
+		threshold:  threshold,
+		interval:   interval,
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Run blocks until ctx is cancelled.
+func (m *DepositMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		err := m.Check(ctx)
+		if err != nil {
+			log.Printf("deposit monitor (chain %d): %v", m.chainId, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *DepositMonitor) Check(ctx context.Context) error {
+	info, err := m.reader.GetPaymasterDepositInfo()
+	if err != nil {
+		return err
+	}
+
+	if info.Deposit.Cmp(m.threshold) >= 0 {
+		if m.low {
+			log.Printf("deposit monitor (chain %d): paymaster %s deposit recovered to %s wei", m.chainId, info.Address, info.Deposit)
+		}
+		m.low = false
+		return nil
+	}
+	if m.low {
+		return nil
+	}
+
+	alert := LowDepositAlert{
+		ChainID:   m.chainId,
+		Paymaster: info.Address,
+		Deposit:   info.Deposit,
+		Threshold: m.threshold,
+		Time:      time.Now(),
+	}
+	log.Printf("deposit monitor (chain %d): paymaster %s deposit %s wei is below the threshold of %s wei", m.chainId, alert.Paymaster, alert.Deposit, alert.Threshold)
+
+	if m.webhookURL != "" {
+		// retried on the next poll
+		err = m.notify(ctx, alert)
+		if err != nil {
+			return err
+		}
+	}
+	m.low = true
+	return nil
+}
+
+func (m *DepositMonitor) notify(ctx context.Context, alert LowDepositAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("alert webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
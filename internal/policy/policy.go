@@ -0,0 +1,252 @@
+package policy
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/store"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	defaultValidity = 1 * time.Hour
+	dailyWindow     = 24 * time.Hour
+)
+
+// RejectionError is returned when a policy refuses to sponsor an operation.
+type RejectionError struct {
+	Policy string
+	Reason string
+}
+
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("sponsorship rejected by policy %q: %s", e.Policy, e.Reason)
+}
+
+func (e *RejectionError) ErrorKind() apperr.Kind {
+	return apperr.KindPolicyDenied
+}
+
+// Call is one call made by the operation being evaluated.
+type Call struct {
+	Target common.Address
+	Data   []byte
+}
+
+type Request struct {
+	Wallet        common.Address
+	UserOperation model.UserOperation
+	Calls         []Call
+}
+
+// Decision is the paymaster signature validity window for an accepted
+// operation.
+type Decision struct {
+	ValidAfter time.Time
+	ValidUntil time.Time
+}
+
+// Engine evaluates every enabled policy in the store against a request. An
+// operation is sponsored only when all of them accept it; with no enabled
+// policy everything is sponsored. Spend limits count the sponsored gas cost
+// of the engine's chain only.
+type Engine struct {
+	store   store.Store
+	chainId int64
+	now     func() time.Time
+
+	// held from the spend check to the recording of an operation, see
+	// Reserve
+	mu sync.Mutex
+}
+
+func NewEngine(store store.Store, chainId int64) *Engine {
+	return &Engine{
+		store:   store,
+		chainId: chainId,
+		now:     time.Now,
+	}
+}
+
+// Evaluate decides whether req is sponsored and for how long the paymaster
+// signature is valid. Its spend check is advisory: Reserve checks it again
+// when the operation is sent.
+func (e *Engine) Evaluate(req Request) (Decision, error) {
+	now := e.now()
+	decision := Decision{
+		ValidAfter: now,
+		ValidUntil: now.Add(defaultValidity),
+	}
+
+	policies, err := e.store.GetAllPolicies()
+	if err != nil {
+		return Decision{}, err
+	}
+
+	cost := req.UserOperation.MaxGasCost()
+	spent := newSpentGas(e, req.Wallet, now)
+
+	for _, policy := range policies {
+		if !policy.Enabled {
+			continue
+		}
+		reject := func(format string, args ...interface{}) (Decision, error) {
+			return Decision{}, &RejectionError{Policy: policy.Name, Reason: fmt.Sprintf(format, args...)}
+		}
+
+		if policy.ActiveFrom != nil && now.Before(*policy.ActiveFrom) {
+			return reject("not active before %s", policy.ActiveFrom.Format(time.RFC3339))
+		}
+		if policy.ActiveUntil != nil && now.After(*policy.ActiveUntil) {
+			return reject("not active after %s", policy.ActiveUntil.Format(time.RFC3339))
+		}
+
+		if policy.MaxGasCostPerOp != nil && cost.Cmp(policy.MaxGasCostPerOp) > 0 {
+			return reject("max gas cost %s exceeds per operation limit %s", cost, policy.MaxGasCostPerOp)
+		}
+
+		restricted := len(policy.AllowedTargets) > 0 || len(policy.AllowedSelectors) > 0
+		if len(req.Calls) == 0 && restricted && !policy.AllowDeploys {
+			return reject("operations making no call are not allowed")
+		}
+		for _, call := range req.Calls {
+			if !targetAllowed(policy, call.Target) {
+				return reject("target %s is not allowed", call.Target.Hex())
+			}
+			if !selectorAllowed(policy, call.Data) {
+				return reject("function selector %s is not allowed", selectorOf(call.Data))
+			}
+		}
+
+		err = spent.check(policy, cost)
+		if err != nil {
+			return Decision{}, err
+		}
+
+		if policy.ValiditySeconds > 0 {
+			validUntil := now.Add(time.Duration(policy.ValiditySeconds) * time.Second)
+			if validUntil.Before(decision.ValidUntil) {
+				decision.ValidUntil = validUntil
+			}
+		}
+		if policy.ActiveUntil != nil && policy.ActiveUntil.Before(decision.ValidUntil) {
+			decision.ValidUntil = *policy.ActiveUntil
+		}
+	}
+
+	return decision, nil
+}
+
+// Reserve checks the spend limits of every enabled policy against req again
+// and runs record, which has to store the sponsored operation, with every
+// other Reserve of the engine waiting. Operations evaluated at the same time
+// thus cannot all take the same remaining budget. The lock is held by this
+// process only.
+func (e *Engine) Reserve(req Request, record func() error) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	policies, err := e.store.GetAllPolicies()
+	if err != nil {
+		return err
+	}
+
+	cost := req.UserOperation.MaxGasCost()
+	spent := newSpentGas(e, req.Wallet, e.now())
+	for _, policy := range policies {
+		if !policy.Enabled {
+			continue
+		}
+		err = spent.check(policy, cost)
+		if err != nil {
+			return err
+		}
+	}
+	return record()
+}
+
+// spentGas reads the sponsored spend a request counts against, each sum
+// once.
+type spentGas struct {
+	engine *Engine
+	wallet common.Address
+	now    time.Time
+
+	walletSpent, globalSpent *big.Int
+}
+
+func newSpentGas(engine *Engine, wallet common.Address, now time.Time) *spentGas {
+	return &spentGas{engine: engine, wallet: wallet, now: now}
+}
+
+// check rejects an operation costing cost when it would take the wallet's
+// daily budget or the global cap of policy past its limit.
+func (s *spentGas) check(policy model.SponsorshipPolicy, cost *big.Int) error {
+	var err error
+	if policy.WalletDailyGasBudget != nil {
+		if s.walletSpent == nil {
+			s.walletSpent, err = s.engine.store.SponsoredGasCost(s.engine.chainId, s.wallet.Hex(), s.now.Add(-dailyWindow))
+			if err != nil {
+				return err
+			}
+		}
+		total := new(big.Int).Add(s.walletSpent, cost)
+		if total.Cmp(policy.WalletDailyGasBudget) > 0 {
+			return &RejectionError{Policy: policy.Name, Reason: fmt.Sprintf("wallet daily gas budget %s would be exceeded (spent %s)", policy.WalletDailyGasBudget, s.walletSpent)}
+		}
+	}
+
+	if policy.GlobalSpendCap != nil {
+		if s.globalSpent == nil {
+			s.globalSpent, err = s.engine.store.SponsoredGasCost(s.engine.chainId, "", time.Time{})
+			if err != nil {
+				return err
+			}
+		}
+		total := new(big.Int).Add(s.globalSpent, cost)
+		if total.Cmp(policy.GlobalSpendCap) > 0 {
+			return &RejectionError{Policy: policy.Name, Reason: fmt.Sprintf("global spend cap %s would be exceeded (spent %s)", policy.GlobalSpendCap, s.globalSpent)}
+		}
+	}
+	return nil
+}
+
+func targetAllowed(policy model.SponsorshipPolicy, target common.Address) bool {
+	if len(policy.AllowedTargets) == 0 {
+		return true
+	}
+	for _, allowed := range policy.AllowedTargets {
+		if allowed == target {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorAllowed treats call data without a selector as "0x", so plain value
+// transfers can be allowed explicitly.
+func selectorAllowed(policy model.SponsorshipPolicy, data []byte) bool {
+	if len(policy.AllowedSelectors) == 0 {
+		return true
+	}
+	selector := selectorOf(data)
+	for _, allowed := range policy.AllowedSelectors {
+		if strings.EqualFold(allowed, selector) {
+			return true
+		}
+	}
+	return false
+}
+
+func selectorOf(data []byte) string {
+	if len(data) < 4 {
+		return "0x"
+	}
+	return hexutil.Encode(data[:4])
+}
@@ -0,0 +1,243 @@
+package policy
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/store"
+	"web3-account-abstraction-api/internal/store/migration"
+	sqlite_store "web3-account-abstraction-api/internal/store/sqlite"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	now       = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	wallet    = common.HexToAddress("0x00000000000000000000000000000000000000c3")
+	paymaster = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	token     = common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	transfer  = common.FromHex("0xa9059cbb")
+	approve   = common.FromHex("0x095ea7b3")
+)
+
+func newTestEngine(t *testing.T, policies ...model.SponsorshipPolicy) (*Engine, store.Store) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "policy.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	migrations, err := sqlite_store.Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = migration.Up(db, migrations, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sqlite_store.NewStore(db)
+
+	for _, policy := range policies {
+		policy.Enabled = true
+		_, err = s.CreatePolicy(policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	engine := NewEngine(s, 1)
+	engine.now = func() time.Time { return now }
+	return engine, s
+}
+
+// request is a sponsored operation of wallet costing cost wei at most.
+func request(cost int64, calls ...Call) Request {
+	return Request{
+		Wallet: wallet,
+		UserOperation: model.UserOperation{
+			Sender:       wallet,
+			Nonce:        big.NewInt(0),
+			CallGasLimit: big.NewInt(cost),
+			MaxFeePerGas: big.NewInt(1),
+			Paymaster:    &paymaster,
+		},
+		Calls: calls,
+	}
+}
+
+// sent records req as a pending operation, which counts its maximum cost.
+func sent(s store.Store, hash string, req Request, submittedAt time.Time) error {
+	return s.CreateUserOperation(model.UserOperationRecord{
+		Hash:          hash,
+		ChainID:       1,
+		Wallet:        req.Wallet.Hex(),
+		UserOperation: req.UserOperation,
+		Status:        model.UserOperationStatusPending,
+		SubmittedAt:   submittedAt,
+		UpdatedAt:     submittedAt,
+	})
+}
+
+func TestEvaluateAllowlists(t *testing.T) {
+	allowlisted := model.SponsorshipPolicy{
+		Name:             "token transfers",
+		AllowedTargets:   []model.Address{token},
+		AllowedSelectors: []string{"0xA9059CBB"},
+	}
+	deploys := allowlisted
+	deploys.AllowDeploys = true
+
+	tests := []struct {
+		name   string
+		policy model.SponsorshipPolicy
+		req    Request
+		// empty when sponsored
+		reject string
+	}{
+		{"allowed call", allowlisted, request(1_000, Call{Target: token, Data: transfer}), ""},
+		{"other target", allowlisted, request(1_000, Call{Target: wallet, Data: transfer}), "target"},
+		{"other selector", allowlisted, request(1_000, Call{Target: token, Data: approve}), "selector"},
+		{"plain transfer", allowlisted, request(1_000, Call{Target: token}), "selector"},
+		{"deploy only", allowlisted, request(1_000), "no call"},
+		{"deploy only, allowed", deploys, request(1_000), ""},
+		{"deploy only, no allowlist", model.SponsorshipPolicy{Name: "open"}, request(1_000), ""},
+	}
+	for _, tt := range tests {
+		engine, _ := newTestEngine(t, tt.policy)
+		_, err := engine.Evaluate(tt.req)
+		expectDecision(t, tt.name, err, tt.reject)
+	}
+}
+
+func TestEvaluateBudgets(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy model.SponsorshipPolicy
+		cost   int64
+		reject string
+	}{
+		{"within the per operation limit", model.SponsorshipPolicy{Name: "limit", MaxGasCostPerOp: big.NewInt(1_000)}, 1_000, ""},
+		{"above the per operation limit", model.SponsorshipPolicy{Name: "limit", MaxGasCostPerOp: big.NewInt(1_000)}, 1_001, "per operation limit"},
+		// 3_000 spent by the wallet in the last day, 500 before
+		{"within the daily budget", model.SponsorshipPolicy{Name: "daily", WalletDailyGasBudget: big.NewInt(4_000)}, 1_000, ""},
+		{"above the daily budget", model.SponsorshipPolicy{Name: "daily", WalletDailyGasBudget: big.NewInt(4_000)}, 1_001, "daily gas budget"},
+		// 3_500 spent by the wallet and 2_000 by another
+		{"within the global cap", model.SponsorshipPolicy{Name: "cap", GlobalSpendCap: big.NewInt(6_500)}, 1_000, ""},
+		{"above the global cap", model.SponsorshipPolicy{Name: "cap", GlobalSpendCap: big.NewInt(6_500)}, 1_001, "global spend cap"},
+	}
+	for _, tt := range tests {
+		engine, s := newTestEngine(t, tt.policy)
+		other := request(2_000)
+		other.Wallet = common.HexToAddress("0x00000000000000000000000000000000000000d4")
+		for _, err := range []error{
+			sent(s, "0x01", request(2_000), now.Add(-time.Hour)),
+			sent(s, "0x02", request(1_000), now.Add(-23*time.Hour)),
+			sent(s, "0x03", request(500), now.Add(-25*time.Hour)),
+			sent(s, "0x04", other, now),
+		} {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		_, err := engine.Evaluate(request(tt.cost))
+		expectDecision(t, tt.name, err, tt.reject)
+	}
+}
+
+func TestEvaluateValidityWindow(t *testing.T) {
+	later := now.Add(10 * time.Minute)
+	tomorrow := now.Add(24 * time.Hour)
+	yesterday := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		policies []model.SponsorshipPolicy
+		// zero when rejected
+		validUntil time.Time
+	}{
+		{"no policy", nil, now.Add(defaultValidity)},
+		{"shorter validity", []model.SponsorshipPolicy{{Name: "short", ValiditySeconds: 60}}, now.Add(time.Minute)},
+		{"longer validity", []model.SponsorshipPolicy{{Name: "long", ValiditySeconds: 7_200}}, now.Add(defaultValidity)},
+		{"ends sooner", []model.SponsorshipPolicy{{Name: "ending", ActiveUntil: &later}}, later},
+		{"shortest of all", []model.SponsorshipPolicy{{Name: "short", ValiditySeconds: 300}, {Name: "ending", ActiveUntil: &later}}, now.Add(5 * time.Minute)},
+		{"not active yet", []model.SponsorshipPolicy{{Name: "future", ActiveFrom: &tomorrow}}, time.Time{}},
+		{"no longer active", []model.SponsorshipPolicy{{Name: "past", ActiveUntil: &yesterday}}, time.Time{}},
+	}
+	for _, tt := range tests {
+		engine, _ := newTestEngine(t, tt.policies...)
+		decision, err := engine.Evaluate(request(1_000, Call{Target: token, Data: transfer}))
+		if tt.validUntil.IsZero() {
+			expectDecision(t, tt.name, err, "active")
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !decision.ValidAfter.Equal(now) || !decision.ValidUntil.Equal(tt.validUntil) {
+			t.Errorf("%s: valid from %s until %s, want from %s until %s", tt.name, decision.ValidAfter, decision.ValidUntil, now, tt.validUntil)
+		}
+	}
+}
+
+// TestReserve sends operations that each pass Evaluate alone but only some of
+// which fit the budget together.
+func TestReserve(t *testing.T) {
+	engine, s := newTestEngine(t, model.SponsorshipPolicy{Name: "daily", WalletDailyGasBudget: big.NewInt(3_000)})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted, rejected := 0, 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := request(1_000)
+			err := engine.Reserve(req, func() error {
+				return sent(s, fmt.Sprintf("0x%02x", i), req, now)
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			var rejection *RejectionError
+			switch {
+			case err == nil:
+				accepted++
+			case errors.As(err, &rejection):
+				rejected++
+			default:
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if accepted != 3 || rejected != 7 {
+		t.Errorf("sent %d and rejected %d operations, want 3 and 7", accepted, rejected)
+	}
+}
+
+func expectDecision(t *testing.T, name string, err error, reject string) {
+	t.Helper()
+	if reject == "" {
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		return
+	}
+	var rejection *RejectionError
+	if !errors.As(err, &rejection) {
+		t.Errorf("%s: got %v, want a rejection for %q", name, err, reject)
+		return
+	}
+	if !strings.Contains(rejection.Reason, reject) {
+		t.Errorf("%s: rejected for %q, want %q", name, rejection.Reason, reject)
+	}
+}
@@ -0,0 +1,90 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const feedTimeout = 10 * time.Second
+
+// FeedSource reads the price from a JSON document on disk or over HTTP,
+// typically kept up to date by a separate job:
+//
+//	{"tokenPerEther": "2500000000", "updatedAt": "2024-01-01T00:00:00Z"}
+//
+// A missing updatedAt is taken as the file's modification time, or now for
+// HTTP feeds.
+type FeedSource struct {
+	location string
+	maxAge   time.Duration
+	client   *http.Client
+}
+
+type feedDocument struct {
+	TokenPerEther string     `json:"tokenPerEther"`
+	UpdatedAt     *time.Time `json:"updatedAt"`
+}
+
+func NewFeedSource(location string, maxAge time.Duration) *FeedSource {
+	return &FeedSource{
+		location: location,
+		maxAge:   maxAge,
+		client:   &http.Client{Timeout: feedTimeout},
+	}
+}
+
+func (s *FeedSource) Price(ctx context.Context) (Price, error) {
+	data, updatedAt, err := s.read(ctx)
+	if err != nil {
+		return Price{}, err
+	}
+
+	var document feedDocument
+	err = json.Unmarshal(data, &document)
+	if err != nil {
+		return Price{}, fmt.Errorf("price feed: %w", err)
+	}
+	tokenPerEther, err := parseTokenPerEther(document.TokenPerEther)
+	if err != nil {
+		return Price{}, fmt.Errorf("price feed: %w", err)
+	}
+	if document.UpdatedAt != nil {
+		updatedAt = *document.UpdatedAt
+	}
+
+	price := Price{TokenPerEther: tokenPerEther, UpdatedAt: updatedAt}
+	return price, checkAge(price, s.maxAge)
+}
+
+func (s *FeedSource) read(ctx context.Context) ([]byte, time.Time, error) {
+	if !strings.HasPrefix(s.location, "http://") && !strings.HasPrefix(s.location, "https://") {
+		info, err := os.Stat(s.location)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		data, err := os.ReadFile(s.location)
+		return data, info.ModTime(), err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.location, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, time.Time{}, fmt.Errorf("price feed: unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	return data, time.Now(), err
+}
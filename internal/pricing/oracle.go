@@ -0,0 +1,115 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+	"web3-account-abstraction-api/generated/abi/erc20"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// the subset of Chainlink's AggregatorV3Interface read here
+const aggregatorABI = `[
+	{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+	{"type":"function","name":"latestRoundData","stateMutability":"view","inputs":[],"outputs":[
+		{"name":"roundId","type":"uint80"},
+		{"name":"answer","type":"int256"},
+		{"name":"startedAt","type":"uint256"},
+		{"name":"updatedAt","type":"uint256"},
+		{"name":"answeredInRound","type":"uint80"}
+	]}
+]`
+
+// OracleSource reads a Chainlink style aggregator whose answer is the price
+// of one ether in whole tokens, scaled by the aggregator's decimals.
+type OracleSource struct {
+	aggregator *bind.BoundContract
+	token      *erc20.ERC20
+	maxAge     time.Duration
+
+	// decimals never change, they are read on first use
+	mu             sync.Mutex
+	scaleLoaded    bool
+	tokenDecimals  uint8
+	oracleDecimals uint8
+}
+
+func NewOracleSource(client *ethclient.Client, oracle common.Address, token common.Address, maxAge time.Duration) (*OracleSource, error) {
+	parsed, err := abi.JSON(strings.NewReader(aggregatorABI))
+	if err != nil {
+		return nil, err
+	}
+	tokenContract, err := erc20.NewERC20(token, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OracleSource{
+		aggregator: bind.NewBoundContract(oracle, parsed, client, client, client),
+		token:      tokenContract,
+		maxAge:     maxAge,
+	}, nil
+}
+
+func (s *OracleSource) Price(ctx context.Context) (Price, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	err := s.loadScale(opts)
+	if err != nil {
+		return Price{}, err
+	}
+
+	var out []interface{}
+	err = s.aggregator.Call(opts, &out, "latestRoundData")
+	if err != nil {
+		return Price{}, err
+	}
+	answer := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	updatedAt := *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	if answer.Sign() <= 0 {
+		return Price{}, errors.New("price oracle answered a non-positive price")
+	}
+
+	tokenPerEther := new(big.Int).Mul(answer, pow10(s.tokenDecimals))
+	tokenPerEther.Div(tokenPerEther, pow10(s.oracleDecimals))
+
+	price := Price{
+		TokenPerEther: tokenPerEther,
+		UpdatedAt:     time.Unix(updatedAt.Int64(), 0),
+	}
+	return price, checkAge(price, s.maxAge)
+}
+
+func (s *OracleSource) loadScale(opts *bind.CallOpts) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.scaleLoaded {
+		return nil
+	}
+
+	tokenDecimals, err := s.token.Decimals(opts)
+	if err != nil {
+		return err
+	}
+	var out []interface{}
+	err = s.aggregator.Call(opts, &out, "decimals")
+	if err != nil {
+		return err
+	}
+
+	s.tokenDecimals = tokenDecimals
+	s.oracleDecimals = *abi.ConvertType(out[0], new(uint8)).(*uint8)
+	s.scaleLoaded = true
+	return nil
+}
+
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
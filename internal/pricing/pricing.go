@@ -0,0 +1,136 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+	"web3-account-abstraction-api/internal/apperr"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	TypeFixed  = "fixed"
+	TypeOracle = "oracle"
+	TypeFeed   = "feed"
+)
+
+var (
+	ErrStalePrice = apperr.New(apperr.KindChain, "stale_price", "price is stale")
+
+	ether = big.NewInt(1_000_000_000_000_000_000)
+
+	// the rate the paymaster token was minted at before prices were
+	// configurable: 1 token wei per 100 wei
+	defaultTokenPerEther = big.NewInt(10_000_000_000_000_000)
+)
+
+// Price is how many token base units one ether buys.
+type Price struct {
+	TokenPerEther *big.Int  `json:"tokenPerEther"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// ToToken converts wei to token base units, rounding up so a quote always
+// covers the cost.
+func (p Price) ToToken(wei *big.Int) *big.Int {
+	amount := new(big.Int).Mul(wei, p.TokenPerEther)
+	amount.Add(amount, new(big.Int).Sub(ether, big.NewInt(1)))
+	return amount.Div(amount, ether)
+}
+
+// Source prices the paymaster token in ether.
+type Source interface {
+	Price(ctx context.Context) (Price, error)
+}
+
+// Config selects and configures a Source.
+type Config struct {
+	// fixed (default), oracle or feed
+	Type string `mapstructure:"type"`
+	// fixed: token base units per ether, defaults to 1e16
+	TokenPerEther string `mapstructure:"token_per_ether"`
+	// oracle: Chainlink style aggregator answering the token price of one
+	// ether
+	Oracle string `mapstructure:"oracle"`
+	// feed: path or http(s) URL of a JSON document
+	// {"tokenPerEther": "...", "updatedAt": "..."}
+	Feed string `mapstructure:"feed"`
+	// oracle and feed: prices older than this are refused, 0 accepts any age
+	MaxAge time.Duration `mapstructure:"max_age"`
+}
+
+// New builds the source of cfg. The oracle reads token's decimals through
+// client.
+func New(cfg Config, client *ethclient.Client, token common.Address) (Source, error) {
+	switch cfg.Type {
+	case TypeFixed, "":
+		tokenPerEther := defaultTokenPerEther
+		if cfg.TokenPerEther != "" {
+			var err error
+			tokenPerEther, err = parseTokenPerEther(cfg.TokenPerEther)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return NewFixedSource(tokenPerEther), nil
+	case TypeOracle:
+		if !common.IsHexAddress(cfg.Oracle) {
+			return nil, fmt.Errorf("invalid price oracle address %q", cfg.Oracle)
+		}
+		return NewOracleSource(client, common.HexToAddress(cfg.Oracle), token, cfg.MaxAge)
+	case TypeFeed:
+		if cfg.Feed == "" {
+			return nil, errors.New("price feed is required")
+		}
+		return NewFeedSource(cfg.Feed, cfg.MaxAge), nil
+	default:
+		return nil, fmt.Errorf("unknown price source type %q", cfg.Type)
+	}
+}
+
+// Validate checks cfg without reading any price.
+func (cfg Config) Validate() error {
+	switch cfg.Type {
+	case TypeFixed, "":
+		if cfg.TokenPerEther == "" {
+			return nil
+		}
+		_, err := parseTokenPerEther(cfg.TokenPerEther)
+		return err
+	case TypeOracle:
+		if !common.IsHexAddress(cfg.Oracle) {
+			return fmt.Errorf("invalid price oracle address %q", cfg.Oracle)
+		}
+		return nil
+	case TypeFeed:
+		if cfg.Feed == "" {
+			return errors.New("price feed is required")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown price source type %q", cfg.Type)
+	}
+}
+
+func parseTokenPerEther(s string) (*big.Int, error) {
+	tokenPerEther, ok := new(big.Int).SetString(s, 10)
+	if !ok || tokenPerEther.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid token per ether price %q", s)
+	}
+	return tokenPerEther, nil
+}
+
+func checkAge(price Price, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		return nil
+	}
+	age := time.Since(price.UpdatedAt)
+	if age > maxAge {
+		return fmt.Errorf("%w: updated %s ago", ErrStalePrice, age.Truncate(time.Second))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package reconciler
+
+import (
+	"context"
+	"log"
+	"time"
+	"web3-account-abstraction-api/internal/bundler"
+	contract "web3-account-abstraction-api/internal/contracts"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/store"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	defaultInterval = 5 * time.Second
+	defaultTimeout  = 10 * time.Minute
+
+	// bundlers may not index an operation immediately after accepting it,
+	// so an unknown hash is only treated as dropped after this grace period.
+	dropGracePeriod = 1 * time.Minute
+)
+
+// Reconciler polls the bundler for every pending user operation and records
+// its final status in the store.
+type Reconciler struct {
+	chainId int64
+	store   store.Store
+	bundler bundler.Bundler
+	// the operations it pays for are charged in token, nil when it only
+	// sponsors
+	paymaster common.Address
+	token     *common.Address
+
+	interval time.Duration
+	timeout  time.Duration
+}
+
+func NewReconciler(chainId int64, store store.Store, bundler bundler.Bundler, paymaster common.Address, token *common.Address, interval time.Duration, timeout time.Duration) *Reconciler {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Reconciler{
+		chainId:   chainId,
+		store:     store,
+		bundler:   bundler,
+		paymaster: paymaster,
+		token:     token,
+		interval:  interval,
+		timeout:   timeout,
+	}
+}
+
+// Run blocks until ctx is cancelled.
+func (r *Reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := r.Reconcile()
+			if err != nil {
+				log.Printf("reconciler (chain %d): %v", r.chainId, err)
+			}
+		}
+	}
+}
+
+func (r *Reconciler) Reconcile() error {
+	pending, err := r.store.GetUserOperationsByStatus(r.chainId, model.UserOperationStatusPending)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range pending {
+		err = r.reconcileOne(record)
+		if err != nil {
+			log.Printf("reconciler: %s: %v", record.Hash, err)
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileOne(record model.UserOperationRecord) error {
+	receipt, err := r.bundler.GetUserOperationReceipt(record.Hash)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	age := now.Sub(record.SubmittedAt)
+
+	switch {
+	case receipt != nil:
+		err = r.applyReceipt(&record, receipt)
+		if err != nil {
+			return err
+		}
+	case age > r.timeout:
+		record.Status = model.UserOperationStatusDropped
+		record.Reason = newString("timed out waiting for inclusion")
+	case age > dropGracePeriod:
+		userOp, err := r.bundler.GetUserOperationByHash(record.Hash)
+		if err != nil {
+			return err
+		}
+		if userOp != nil {
+			return nil
+		}
+		record.Status = model.UserOperationStatusDropped
+		record.Reason = newString("no longer known to the bundler")
+	default:
+		return nil
+	}
+
+	record.UpdatedAt = now
+	return r.store.UpdateUserOperation(record)
+}
+
+func (r *Reconciler) applyReceipt(record *model.UserOperationRecord, receipt *bundler.UserOperationReceipt) error {
+	record.Status = model.UserOperationStatusIncluded
+	if !receipt.Success {
+		record.Status = model.UserOperationStatusFailed
+	}
+
+	record.TxHash = newString(receipt.Receipt.TransactionHash.Hex())
+	record.ActualGasCost = receipt.ActualGasCost.ToInt()
+	record.ActualGasUsed = receipt.ActualGasUsed.ToInt()
+	record.Reason = receipt.Reason
+
+	if r.token == nil || receipt.Paymaster == nil || *receipt.Paymaster != r.paymaster {
+		return nil
+	}
+	charged, err := contract.TokenCharged(receipt.Logs, *r.token, receipt.Sender, r.paymaster)
+	if err != nil {
+		return err
+	}
+	record.ActualTokenCost = charged
+	return nil
+}
+
+func newString(s string) *string {
+	return &s
+}
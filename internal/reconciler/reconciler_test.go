@@ -0,0 +1,73 @@
+package reconciler
+
+import (
+	"math/big"
+	"testing"
+	"web3-account-abstraction-api/internal/bundler"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func transferLog(token common.Address, from common.Address, to common.Address, value int64) types.Log {
+	return types.Log{
+		Address: token,
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.BigToHash(big.NewInt(value)).Bytes(),
+	}
+}
+
+func TestApplyReceiptTokenCharge(t *testing.T) {
+	paymaster := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	token := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	sender := common.HexToAddress("0x00000000000000000000000000000000000000c3")
+	friend := common.HexToAddress("0x00000000000000000000000000000000000000d4")
+	otherPaymaster := common.HexToAddress("0x00000000000000000000000000000000000000e5")
+
+	receipt := func(paying common.Address) *bundler.UserOperationReceipt {
+		return &bundler.UserOperationReceipt{
+			Sender:        sender,
+			Paymaster:     &paying,
+			ActualGasCost: (*hexutil.Big)(big.NewInt(1_000)),
+			ActualGasUsed: (*hexutil.Big)(big.NewInt(100)),
+			Success:       true,
+			Logs: []types.Log{
+				// what the operation's call data sends
+				transferLog(token, sender, friend, 500),
+				// what the paymaster takes in postOp
+				transferLog(token, sender, paymaster, 42),
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		receipt *bundler.UserOperationReceipt
+		// nil when no charge is recorded
+		want *big.Int
+	}{
+		{"paid by the paymaster", receipt(paymaster), big.NewInt(42)},
+		{"paid by another paymaster", receipt(otherPaymaster), nil},
+	}
+	for _, tt := range tests {
+		r := NewReconciler(1, nil, nil, paymaster, &token, 0, 0)
+		var record model.UserOperationRecord
+		err := r.applyReceipt(&record, tt.receipt)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		switch {
+		case tt.want == nil && record.ActualTokenCost != nil:
+			t.Errorf("%s: recorded a charge of %s", tt.name, record.ActualTokenCost)
+		case tt.want != nil && (record.ActualTokenCost == nil || record.ActualTokenCost.Cmp(tt.want) != 0):
+			t.Errorf("%s: recorded a charge of %v, want %s", tt.name, record.ActualTokenCost, tt.want)
+		}
+	}
+}
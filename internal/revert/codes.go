@@ -0,0 +1,81 @@
+package revert
+
+// entryPointCodes explains the AAxx prefix of EntryPoint FailedOp reasons.
+// The first digit names the culprit: 1 factory, 2 account, 3 paymaster,
+// 4 and 5 gas accounting, 9 the bundle itself.
+var entryPointCodes = map[string]string{
+	"AA10": "the wallet is already deployed but the operation still carries factory data",
+	"AA13": "the factory failed to deploy the wallet or ran out of verification gas",
+	"AA14": "the factory returned a different address than the operation's sender",
+	"AA15": "the factory did not deploy code at the sender address",
+	"AA20": "the wallet is not deployed and the operation carries no factory data",
+	"AA21": "the wallet's EntryPoint deposit and balance cannot pay for the operation's gas",
+	"AA22": "the wallet's signature has expired or is not valid yet",
+	"AA23": "the wallet's validation reverted or ran out of gas",
+	"AA24": "the signature does not match the wallet owner",
+	"AA25": "the operation's nonce is not the wallet's next nonce",
+	"AA26": "the wallet's validation used more than verificationGasLimit",
+	"AA30": "the paymaster is not deployed",
+	"AA31": "the paymaster's EntryPoint deposit is too low to sponsor the operation",
+	"AA32": "the paymaster's signature has expired or is not valid yet",
+	"AA33": "the paymaster's validation reverted or ran out of gas",
+	"AA34": "the paymaster's signature is invalid",
+	"AA36": "the paymaster's validation used more than paymasterVerificationGasLimit",
+	"AA40": "verification used more than verificationGasLimit",
+	"AA41": "too little verification gas was left to run the operation",
+	"AA50": "the paymaster's postOp reverted",
+	"AA51": "the operation's prefund is below its actual gas cost",
+	"AA90": "the bundle's beneficiary is invalid",
+	"AA91": "the EntryPoint failed to pay the bundle's beneficiary",
+	"AA92": "an EntryPoint internal call was made from outside",
+	"AA93": "paymasterAndData is too short",
+	"AA94": "a gas value of the operation overflows",
+	"AA95": "the bundle ran out of gas",
+	"AA96": "the signature aggregator is invalid",
+}
+
+// panicCodes explains the Panic(uint256) codes of the Solidity compiler.
+var panicCodes = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "corrupted storage byte array",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "too much memory allocated",
+	0x51: "call to an uninitialized function",
+}
+
+// rejectionCodes names the JSON-RPC error codes ERC-7769 assigns to bundler
+// rejections.
+var rejectionCodes = map[int]string{
+	-32500: "rejected by the EntryPoint or the wallet during validation",
+	-32501: "rejected by the paymaster during validation",
+	-32502: "rejected for using a banned opcode",
+	-32503: "rejected for being out of its validity time range",
+	-32504: "rejected because the paymaster or factory is throttled or banned",
+	-32505: "rejected because the paymaster or factory stake is too low",
+	-32506: "rejected because the signature aggregator is not supported",
+	-32507: "rejected because the signature check failed",
+}
+
+// customMessages explains the custom errors of the abis/ contracts. Errors
+// not listed are described by their name and arguments.
+var customMessages = map[string]string{
+	"ECDSAInvalidSignature":        "the signature is invalid",
+	"ECDSAInvalidSignatureLength":  "the signature has the wrong length",
+	"ECDSAInvalidSignatureS":       "the signature's s value is malleable",
+	"SafeERC20FailedOperation":     "an ERC-20 transfer failed",
+	"ERC20InsufficientAllowance":   "the token allowance is too low",
+	"ERC20InsufficientBalance":     "the token balance is too low",
+	"ERC20InvalidApprover":         "the token approver is invalid",
+	"ERC20InvalidReceiver":         "the token receiver is invalid",
+	"ERC20InvalidSender":           "the token sender is invalid",
+	"ERC20InvalidSpender":          "the token spender is invalid",
+	"OwnableInvalidOwner":          "the new owner is invalid",
+	"OwnableUnauthorizedAccount":   "the caller is not the owner",
+	"ReentrancyGuardReentrantCall": "the EntryPoint was re-entered",
+	"SenderAddressResult":          "the EntryPoint returned the sender address",
+}
@@ -0,0 +1,283 @@
+package revert
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"web3-account-abstraction-api/generated/abi/account"
+	"web3-account-abstraction-api/generated/abi/accountfactory"
+	"web3-account-abstraction-api/generated/abi/entrypoint"
+	"web3-account-abstraction-api/generated/abi/entrypointv06"
+	"web3-account-abstraction-api/generated/abi/erc20"
+	"web3-account-abstraction-api/generated/abi/paymaster"
+	"web3-account-abstraction-api/generated/abi/paymasterv06"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	errorSelector = [4]byte(crypto.Keccak256([]byte("Error(string)"))[:4])
+	panicSelector = [4]byte(crypto.Keccak256([]byte("Panic(uint256)"))[:4])
+
+	entryPointCode = regexp.MustCompile(`\bAA\d\d\b`)
+	hexData        = regexp.MustCompile(`0x[0-9a-fA-F]{8,}`)
+)
+
+// Error is a decoded revert or bundler rejection.
+type Error struct {
+	// AAxx for EntryPoint rejections, otherwise the Solidity error name
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// the reason string of FailedOp and Error(string), or the bundler's
+	// message when there was no revert data to decode
+	Reason string `json:"reason,omitempty"`
+	// the Solidity error decoded, empty when only a message was available
+	Name string                 `json:"name,omitempty"`
+	Args map[string]interface{} `json:"args,omitempty"`
+	// the revert of the inner call, for FailedOpWithRevert and PostOpReverted
+	Cause *Error `json:"cause,omitempty"`
+	// JSON-RPC error code of a bundler rejection
+	RPCCode int           `json:"rpcCode,omitempty"`
+	Data    hexutil.Bytes `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("%s: %s", e.Code, e.Message)
+	if e.Cause != nil {
+		message = fmt.Sprintf("%s (%s)", message, e.Cause.Error())
+	}
+	return message
+}
+
+var knownErrorAbis = func() []*abi.ABI {
+	result := []*abi.ABI{}
+	for _, metadata := range []interface{ GetAbi() (*abi.ABI, error) }{
+		entrypoint.EntryPointMetaData,
+		entrypointv06.EntryPointV06MetaData,
+		account.AccountMetaData,
+		accountfactory.AccountFactoryMetaData,
+		paymaster.PaymasterMetaData,
+		paymasterv06.PaymasterV06MetaData,
+		erc20.ERC20MetaData,
+	} {
+		parsed, err := metadata.GetAbi()
+		if err == nil {
+			result = append(result, parsed)
+		}
+	}
+	return result
+}()
+
+// Decode decodes revert data. Data no known error matches is returned as
+// UnknownRevert.
+func Decode(data []byte) *Error {
+	if len(data) == 0 {
+		return &Error{Code: "Reverted", Message: "reverted without a reason"}
+	}
+	decoded := decodeKnown(data)
+	if decoded == nil {
+		return &Error{Code: "UnknownRevert", Message: "reverted with an unknown error", Data: data}
+	}
+	return decoded
+}
+
+// decodeKnown returns nil unless data is a known error.
+func decodeKnown(data []byte) *Error {
+	if len(data) < 4 {
+		return nil
+	}
+	selector := [4]byte(data[:4])
+
+	switch selector {
+	case errorSelector:
+		stringType, _ := abi.NewType("string", "", nil)
+		values, err := abi.Arguments{{Type: stringType}}.Unpack(data[4:])
+		if err != nil {
+			return nil
+		}
+		decoded := fromReasonString(values[0].(string), "Error")
+		decoded.Name = "Error"
+		decoded.Data = data
+		return decoded
+	case panicSelector:
+		uint256Type, _ := abi.NewType("uint256", "", nil)
+		values, err := abi.Arguments{{Type: uint256Type}}.Unpack(data[4:])
+		if err != nil {
+			return nil
+		}
+		code := values[0].(*big.Int)
+		message, ok := "", false
+		if code.IsUint64() {
+			message, ok = panicCodes[code.Uint64()]
+		}
+		if !ok {
+			message = "unknown panic"
+		}
+		return &Error{
+			Code:    "Panic",
+			Message: fmt.Sprintf("%s (0x%x)", message, code),
+			Name:    "Panic",
+			Args:    map[string]interface{}{"code": code},
+			Data:    data,
+		}
+	}
+
+	for _, parsed := range knownErrorAbis {
+		abiError, err := parsed.ErrorByID(selector)
+		if err != nil {
+			continue
+		}
+		values, err := abiError.Inputs.Unpack(data[4:])
+		if err != nil {
+			continue
+		}
+		return decodeError(abiError, values, data)
+	}
+	return nil
+}
+
+func decodeError(abiError *abi.Error, values []interface{}, data []byte) *Error {
+	args := map[string]interface{}{}
+	for i, input := range abiError.Inputs {
+		name := input.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		args[name] = jsonValue(values[i])
+	}
+
+	var decoded *Error
+	switch abiError.Name {
+	case "FailedOp", "FailedOpWithRevert":
+		decoded = fromReasonString(values[1].(string), abiError.Name)
+		if abiError.Name == "FailedOpWithRevert" {
+			decoded.Cause = Decode(values[2].([]byte))
+		}
+	case "PostOpReverted":
+		decoded = &Error{
+			Code:    abiError.Name,
+			Message: "the paymaster's postOp reverted",
+			Cause:   Decode(values[0].([]byte)),
+		}
+	case "SignatureValidationFailed":
+		decoded = &Error{
+			Code:    abiError.Name,
+			Message: fmt.Sprintf("the signature aggregator %s rejected the signature", values[0].(common.Address)),
+		}
+	default:
+		message, ok := customMessages[abiError.Name]
+		if !ok {
+			message = fmt.Sprintf("reverted with %s", abiError.Sig)
+		}
+		decoded = &Error{Code: abiError.Name, Message: message}
+	}
+	decoded.Name = abiError.Name
+	decoded.Args = args
+	decoded.Data = data
+	return decoded
+}
+
+// fromReasonString explains reason by its AAxx code, if it has one.
+func fromReasonString(reason string, fallbackCode string) *Error {
+	code := entryPointCode.FindString(reason)
+	if message, ok := entryPointCodes[code]; ok {
+		return &Error{Code: code, Message: message, Reason: reason}
+	}
+	if code != "" {
+		return &Error{Code: code, Message: reason, Reason: reason}
+	}
+	return &Error{Code: fallbackCode, Message: reason, Reason: reason}
+}
+
+// FromReason decodes a revert reason as bundlers and receipts report it: hex
+// revert data, or a message carrying revert data or an AAxx code. It returns
+// nil when reason holds neither.
+func FromReason(reason string) *Error {
+	trimmed := strings.TrimSpace(reason)
+	if trimmed == "" {
+		return nil
+	}
+	if strings.HasPrefix(trimmed, "0x") {
+		data, err := hexutil.Decode(trimmed)
+		if err == nil {
+			return Decode(data)
+		}
+	}
+	for _, match := range hexData.FindAllString(reason, -1) {
+		data, err := hexutil.Decode(match)
+		if err != nil {
+			continue
+		}
+		if decoded := decodeKnown(data); decoded != nil {
+			return decoded
+		}
+	}
+	if entryPointCode.MatchString(reason) {
+		return fromReasonString(reason, "")
+	}
+	return nil
+}
+
+// FromError decodes the revert or bundler rejection err carries, nil when
+// it carries none.
+func FromError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var decoded *Error
+	if errors.As(err, &decoded) {
+		return decoded
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		decoded = fromErrorData(dataErr.ErrorData())
+	}
+	if decoded == nil {
+		decoded = FromReason(err.Error())
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		if rejection, ok := rejectionCodes[rpcErr.ErrorCode()]; ok {
+			if decoded == nil {
+				decoded = &Error{Code: "BundlerRejected", Message: rejection, Reason: rpcErr.Error()}
+			}
+			decoded.RPCCode = rpcErr.ErrorCode()
+		}
+	}
+	return decoded
+}
+
+// fromErrorData reads the data of a JSON-RPC error: revert data as a hex
+// string, or an object carrying it.
+func fromErrorData(data interface{}) *Error {
+	switch value := data.(type) {
+	case string:
+		return FromReason(value)
+	case map[string]interface{}:
+		for _, key := range []string{"revertData", "data", "reason"} {
+			if decoded := fromErrorData(value[key]); decoded != nil {
+				return decoded
+			}
+		}
+	}
+	return nil
+}
+
+// jsonValue converts ABI values that would marshal as byte arrays to hex.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return hexutil.Bytes(v)
+	case [32]byte:
+		return common.Hash(v)
+	}
+	return value
+}
@@ -0,0 +1,102 @@
+package revert
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// revert data as the EVM returns it
+const (
+	// Error("insufficient balance")
+	errorString = "0x08c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000014696e73756666696369656e742062616c616e6365000000000000000000000000"
+	// Error("AA21 didn't pay prefund")
+	errorEntryPointCode = "0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001741413231206469646e2774207061792070726566756e64000000000000000000"
+	// Panic(0x11)
+	panicOverflow = "0x4e487b710000000000000000000000000000000000000000000000000000000000000011"
+	// ERC20InsufficientBalance(0x…c3, 5, 42)
+	insufficientBalance = "0xe450d38c00000000000000000000000000000000000000000000000000000000000000c30000000000000000000000000000000000000000000000000000000000000005000000000000000000000000000000000000000000000000000000000000002a"
+	// OwnableUnauthorizedAccount(0x…c3)
+	unauthorizedAccount = "0x118cdaa700000000000000000000000000000000000000000000000000000000000000c3"
+	// FailedOp(0, "AA25 invalid account nonce")
+	failedOp = "0x220266b600000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000040000000000000000000000000000000000000000000000000000000000000001a4141323520696e76616c6964206163636f756e74206e6f6e6365000000000000"
+	// FailedOpWithRevert(0, "AA23 reverted", Error("insufficient balance"))
+	failedOpWithRevert = "0x65c8fd4d0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000006000000000000000000000000000000000000000000000000000000000000000a0000000000000000000000000000000000000000000000000000000000000000d4141323320726576657274656400000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000006408c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000014696e73756666696369656e742062616c616e636500000000000000000000000000000000000000000000000000000000000000000000000000000000"
+	// PostOpReverted(ERC20InsufficientBalance(0x…c3, 5, 42))
+	postOpReverted = "0xad7954bc00000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000064e450d38c00000000000000000000000000000000000000000000000000000000000000c30000000000000000000000000000000000000000000000000000000000000005000000000000000000000000000000000000000000000000000000000000002a00000000000000000000000000000000000000000000000000000000"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		code    string
+		message string
+		reason  string
+		// empty when the error has no cause
+		cause string
+	}{
+		{"no data", "0x", "Reverted", "without a reason", "", ""},
+		{"Error(string)", errorString, "Error", "insufficient balance", "insufficient balance", ""},
+		{"Error(string) with an EntryPoint code", errorEntryPointCode, "AA21", "cannot pay", "AA21 didn't pay prefund", ""},
+		{"Panic(uint256)", panicOverflow, "Panic", "arithmetic overflow or underflow (0x11)", "", ""},
+		{"custom error", insufficientBalance, "ERC20InsufficientBalance", "token balance is too low", "", ""},
+		{"custom error of the paymaster", unauthorizedAccount, "OwnableUnauthorizedAccount", "not the owner", "", ""},
+		{"unknown custom error", "0xdeadbeef", "UnknownRevert", "unknown error", "", ""},
+		{"FailedOp", failedOp, "AA25", "nonce", "AA25 invalid account nonce", ""},
+		{"FailedOpWithRevert", failedOpWithRevert, "AA23", "validation reverted", "AA23 reverted", "Error"},
+		{"PostOpReverted", postOpReverted, "PostOpReverted", "postOp reverted", "", "ERC20InsufficientBalance"},
+	}
+	for _, tt := range tests {
+		decoded := Decode(common.FromHex(tt.data))
+		if decoded.Code != tt.code || !strings.Contains(decoded.Message, tt.message) || decoded.Reason != tt.reason {
+			t.Errorf("%s: got %s %q (reason %q), want %s %q (reason %q)", tt.name, decoded.Code, decoded.Message, decoded.Reason, tt.code, tt.message, tt.reason)
+		}
+		switch {
+		case tt.cause == "" && decoded.Cause != nil:
+			t.Errorf("%s: got cause %s", tt.name, decoded.Cause)
+		case tt.cause != "" && (decoded.Cause == nil || decoded.Cause.Code != tt.cause):
+			t.Errorf("%s: got cause %v, want %s", tt.name, decoded.Cause, tt.cause)
+		}
+	}
+}
+
+func TestDecodeArgs(t *testing.T) {
+	decoded := Decode(common.FromHex(insufficientBalance))
+	if decoded.Args["sender"] != common.HexToAddress("0xc3") {
+		t.Errorf("got sender %v", decoded.Args["sender"])
+	}
+	if balance, ok := decoded.Args["balance"].(*big.Int); !ok || balance.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("got balance %v, want 5", decoded.Args["balance"])
+	}
+
+	nested := Decode(common.FromHex(failedOpWithRevert))
+	if nested.Cause == nil || nested.Cause.Reason != "insufficient balance" {
+		t.Errorf("got cause %v, want the inner Error(string)", nested.Cause)
+	}
+}
+
+func TestFromReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		reason string
+		// empty when nothing is decoded
+		code string
+	}{
+		{"hex revert data", failedOp, "AA25"},
+		{"message carrying revert data", "execution reverted: " + insufficientBalance, "ERC20InsufficientBalance"},
+		{"message carrying an EntryPoint code", "FailedOp(0,AA33 reverted)", "AA33"},
+		{"plain message", "nonce too low", ""},
+	}
+	for _, tt := range tests {
+		decoded := FromReason(tt.reason)
+		switch {
+		case tt.code == "" && decoded != nil:
+			t.Errorf("%s: got %s", tt.name, decoded)
+		case tt.code != "" && (decoded == nil || decoded.Code != tt.code):
+			t.Errorf("%s: got %v, want %s", tt.name, decoded, tt.code)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// KeySigner signs with a private key held in memory.
+type KeySigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+func NewKeySigner(privateKey *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+}
+
+// ParseKey takes a hex private key, with or without 0x.
+func ParseKey(key string) (*KeySigner, error) {
+	privateKey, err := crypto.HexToECDSA(common.Bytes2Hex(common.FromHex(key)))
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+	return NewKeySigner(privateKey), nil
+}
+
+// NewKeystoreSigner decrypts a go-ethereum keystore JSON file.
+func NewKeystoreSigner(path string, password string) (*KeySigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("keystore %s: %w", path, err)
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+func (s *KeySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *KeySigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.privateKey)
+}
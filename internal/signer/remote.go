@@ -0,0 +1,174 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The remote signer protocol:
+//
+//	GET  /address                       -> {"address": "0x..."}
+//	POST /sign {"hash": "0x<32 bytes>"} -> {"signature": "0x<65 bytes>"}
+//
+// Requests carry "Authorization: Bearer <token>" when a token is configured.
+// Errors are any non 200 status with a plain text body.
+
+type addressResponse struct {
+	Address common.Address `json:"address"`
+}
+
+type signRequest struct {
+	Hash hexutil.Bytes `json:"hash"`
+}
+
+type signResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// RemoteSigner asks a signer service over HTTP. Every returned signature is
+// checked to recover to the signer's address.
+type RemoteSigner struct {
+	url     string
+	token   string
+	address common.Address
+	client  *http.Client
+}
+
+// NewRemoteSigner asks the service for its address when address is zero.
+func NewRemoteSigner(url string, token string, address common.Address) (*RemoteSigner, error) {
+	if url == "" {
+		return nil, errors.New("remote signer url is required")
+	}
+
+	s := &RemoteSigner{
+		url:     strings.TrimRight(url, "/"),
+		token:   token,
+		address: address,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+	if address == (common.Address{}) {
+		var response addressResponse
+		err := s.do(http.MethodGet, "/address", nil, &response)
+		if err != nil {
+			return nil, err
+		}
+		s.address = response.Address
+	}
+	return s, nil
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *RemoteSigner) SignHash(hash []byte) ([]byte, error) {
+	var response signResponse
+	err := s.do(http.MethodPost, "/sign", signRequest{Hash: hash}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := normalizeSignature(response.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	publicKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	if crypto.PubkeyToAddress(*publicKey) != s.address {
+		return nil, fmt.Errorf("remote signer: signature is not from %s", s.address)
+	}
+	return signature, nil
+}
+
+func (s *RemoteSigner) do(method string, path string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, s.url+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("remote signer: %s: %s", res.Status, strings.TrimSpace(string(message)))
+	}
+	return json.NewDecoder(res.Body).Decode(result)
+}
+
+// Handler serves the remote signer protocol for s, e.g. as a local stand-in
+// for the real signing service. An empty token accepts every request.
+func Handler(s Signer, token string) http.Handler {
+	mux := http.NewServeMux()
+	authorized := func(req *http.Request) bool {
+		if token == "" {
+			return true
+		}
+		return subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), []byte("Bearer "+token)) == 1
+	}
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+
+	mux.HandleFunc("GET /address", func(w http.ResponseWriter, req *http.Request) {
+		if !authorized(req) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, addressResponse{Address: s.Address()})
+	})
+	mux.HandleFunc("POST /sign", func(w http.ResponseWriter, req *http.Request) {
+		if !authorized(req) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var request signRequest
+		err := json.NewDecoder(req.Body).Decode(&request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(request.Hash) != 32 {
+			http.Error(w, "hash must be 32 bytes", http.StatusBadRequest)
+			return
+		}
+
+		signature, err := s.SignHash(request.Hash)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, signResponse{Signature: signature})
+	})
+	return mux
+}
@@ -0,0 +1,79 @@
+package signer
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func newTestKey(t *testing.T) *KeySigner {
+	t.Helper()
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewKeySigner(privateKey)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key := newTestKey(t)
+	server := httptest.NewServer(Handler(key, "secret"))
+	defer server.Close()
+
+	remote, err := NewRemoteSigner(server.URL+"/", "secret", common.Address{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remote.Address() != key.Address() {
+		t.Fatalf("address %s, want %s", remote.Address(), key.Address())
+	}
+
+	hash := crypto.Keccak256([]byte("user operation"))
+	signature, err := remote.SignHash(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crypto.PubkeyToAddress(*publicKey) != key.Address() {
+		t.Errorf("signature recovers to %s, want %s", crypto.PubkeyToAddress(*publicKey), key.Address())
+	}
+}
+
+func TestRemoteSignerWrongToken(t *testing.T) {
+	server := httptest.NewServer(Handler(newTestKey(t), "secret"))
+	defer server.Close()
+
+	_, err := NewRemoteSigner(server.URL, "guess", common.Address{})
+	if err == nil {
+		t.Fatal("got the address with a wrong token")
+	}
+
+	remote, err := NewRemoteSigner(server.URL, "", common.HexToAddress("0x01"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = remote.SignHash(crypto.Keccak256(nil))
+	if err == nil {
+		t.Fatal("signed without a token")
+	}
+}
+
+func TestRemoteSignerOtherKey(t *testing.T) {
+	server := httptest.NewServer(Handler(newTestKey(t), ""))
+	defer server.Close()
+
+	expected := newTestKey(t).Address()
+	remote, err := NewRemoteSigner(server.URL, "", expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = remote.SignHash(crypto.Keccak256(nil))
+	if err == nil {
+		t.Fatalf("accepted a signature that is not from %s", expected)
+	}
+}
@@ -0,0 +1,135 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	TypeKey      = "key"
+	TypeKeystore = "keystore"
+	TypeRemote   = "remote"
+)
+
+// Signer holds one Ethereum account's key, wherever it lives.
+type Signer interface {
+	Address() common.Address
+	// SignHash returns the 65 byte [R || S || V] signature of hash, V being
+	// 0 or 1.
+	SignHash(hash []byte) ([]byte, error)
+}
+
+// Config selects and configures a Signer.
+type Config struct {
+	// key (default), keystore or remote
+	Type string `mapstructure:"type"`
+	// key: hex private key
+	Key string `mapstructure:"key"`
+	// keystore: go-ethereum keystore JSON file and its password
+	Path     string `mapstructure:"path"`
+	Password string `mapstructure:"password"`
+	// remote: base URL of the signer, optional bearer token and the address
+	// it must sign for (asked from the signer when empty)
+	URL     string `mapstructure:"url"`
+	Token   string `mapstructure:"token"`
+	Address string `mapstructure:"address"`
+}
+
+func (cfg Config) IsZero() bool {
+	return cfg == Config{}
+}
+
+func New(cfg Config) (Signer, error) {
+	switch cfg.Type {
+	case TypeKey, "":
+		return ParseKey(cfg.Key)
+	case TypeKeystore:
+		return NewKeystoreSigner(cfg.Path, cfg.Password)
+	case TypeRemote:
+		var address common.Address
+		if cfg.Address != "" {
+			if !common.IsHexAddress(cfg.Address) {
+				return nil, fmt.Errorf("invalid remote signer address %q", cfg.Address)
+			}
+			address = common.HexToAddress(cfg.Address)
+		}
+		return NewRemoteSigner(cfg.URL, cfg.Token, address)
+	default:
+		return nil, fmt.Errorf("unknown signer type %q", cfg.Type)
+	}
+}
+
+// PersonalSign signs data as eth_sign does, with V 27 or 28.
+func PersonalSign(s Signer, data []byte) ([]byte, error) {
+	signature, err := s.SignHash(accounts.TextHash(data))
+	if err != nil {
+		return nil, err
+	}
+
+	signature[64] += 27
+	return signature, nil
+}
+
+// TransactOpts sends transactions from s on chainId.
+func TransactOpts(s Signer, chainId *big.Int) *bind.TransactOpts {
+	txSigner := types.LatestSignerForChainID(chainId)
+	return &bind.TransactOpts{
+		From: s.Address(),
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != s.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			signature, err := s.SignHash(txSigner.Hash(tx).Bytes())
+			if err != nil {
+				return nil, err
+			}
+			return tx.WithSignature(txSigner, signature)
+		},
+		Context: context.Background(),
+	}
+}
+
+// normalizeSignature accepts V as 0/1 or 27/28 and returns it as 0/1.
+func normalizeSignature(signature []byte) ([]byte, error) {
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("signature must be 65 bytes, got %d", len(signature))
+	}
+
+	result := common.CopyBytes(signature)
+	if result[64] >= 27 {
+		result[64] -= 27
+	}
+	if result[64] > 1 {
+		return nil, errors.New("invalid signature recovery id")
+	}
+	return result, nil
+}
+
+// Validate checks cfg without contacting a remote signer; keys and keystores
+// are fully loaded.
+func (cfg Config) Validate() error {
+	if cfg.Type != TypeRemote {
+		_, err := New(cfg)
+		return err
+	}
+
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("remote signer url %q must be http or https", cfg.URL)
+	}
+	if cfg.Address != "" && !common.IsHexAddress(cfg.Address) {
+		return fmt.Errorf("invalid remote signer address %q", cfg.Address)
+	}
+	return nil
+}
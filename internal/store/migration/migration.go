@@ -0,0 +1,247 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// migration files are named <version>_<name>.<up|down>.sql
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Lock is held for a whole Up or Down run, so instances sharing a database
+// never migrate it at the same time. Both statements run on the connection
+// the migrations run on.
+type Lock struct {
+	Acquire string
+	Release string
+}
+
+// conn is a *sql.DB or the *sql.Conn a run holds its lock on.
+type conn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+type Status struct {
+	Version int
+	Name    string
+	Applied bool
+}
+
+// Load reads every migration in fsys, ordered by version. Each version needs
+// both an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %q", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d named both %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+func ensureVersionTable(db conn) error {
+	_, err := db.ExecContext(context.Background(), `
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	return err
+}
+
+func appliedVersions(db conn) (map[int]bool, error) {
+	err := ensureVersionTable(db)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(context.Background(), `SELECT version FROM schema_version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]bool{}
+	for rows.Next() {
+		var version int
+		err = rows.Scan(&version)
+		if err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// Up applies every migration not yet recorded in schema_version, each in its
+// own transaction, holding lock unless it is nil. It returns how many were
+// applied.
+func Up(db *sql.DB, migrations []Migration, lock *Lock) (count int, err error) {
+	c, err := locked(db, lock)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, c.unlock())
+	}()
+
+	applied, err := appliedVersions(c)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+		// version and name come from validated file names, so formatting
+		// them in keeps this free of driver specific placeholders
+		err = run(c, m.Up, fmt.Sprintf(
+			`INSERT INTO schema_version(version, name) VALUES (%d, '%s')`, m.Version, m.Name))
+		if err != nil {
+			return count, fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Down reverts the latest steps applied migrations, holding lock unless it
+// is nil. It returns how many were reverted.
+func Down(db *sql.DB, migrations []Migration, steps int, lock *Lock) (count int, err error) {
+	c, err := locked(db, lock)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, c.unlock())
+	}()
+
+	applied, err := appliedVersions(c)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := len(migrations) - 1; i >= 0 && count < steps; i-- {
+		m := migrations[i]
+		if !applied[m.Version] {
+			continue
+		}
+		err = run(c, m.Down, fmt.Sprintf(
+			`DELETE FROM schema_version WHERE version = %d`, m.Version))
+		if err != nil {
+			return count, fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Statuses reports, in version order, whether each migration is applied.
+func Statuses(db *sql.DB, migrations []Migration) ([]Status, error) {
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Status, len(migrations))
+	for i, m := range migrations {
+		result[i] = Status{Version: m.Version, Name: m.Name, Applied: applied[m.Version]}
+	}
+	return result, nil
+}
+
+// lockedConn is the connection a run holds its lock on.
+type lockedConn struct {
+	*sql.Conn
+	lock *Lock
+}
+
+func locked(db *sql.DB, lock *Lock) (lockedConn, error) {
+	c, err := db.Conn(context.Background())
+	if err != nil {
+		return lockedConn{}, err
+	}
+	if lock != nil {
+		_, err = c.ExecContext(context.Background(), lock.Acquire)
+		if err != nil {
+			c.Close()
+			return lockedConn{}, fmt.Errorf("migration lock: %w", err)
+		}
+	}
+	return lockedConn{Conn: c, lock: lock}, nil
+}
+
+// unlock releases the lock and the connection.
+func (c lockedConn) unlock() error {
+	var err error
+	if c.lock != nil {
+		_, err = c.ExecContext(context.Background(), c.lock.Release)
+	}
+	return errors.Join(err, c.Close())
+}
+
+func run(db conn, statements ...string) error {
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		_, err = tx.Exec(statement)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,25 @@
+package postgres_store
+
+import (
+	"embed"
+	"io/fs"
+	"web3-account-abstraction-api/internal/store/migration"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+func Migrations() ([]migration.Migration, error) {
+	files, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return migration.Load(files)
+}
+
+// MigrationLock is a session advisory lock, so instances started together
+// with --migrate apply each migration once.
+var MigrationLock = &migration.Lock{
+	Acquire: `SELECT pg_advisory_lock(hashtext('schema_version'))`,
+	Release: `SELECT pg_advisory_unlock(hashtext('schema_version'))`,
+}
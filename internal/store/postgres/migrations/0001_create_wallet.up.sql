@@ -0,0 +1,5 @@
+CREATE TABLE wallet (
+	chain_id BIGINT NOT NULL,
+	address TEXT NOT NULL,
+	PRIMARY KEY (chain_id, address)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE user_operation (
+	hash TEXT PRIMARY KEY,
+	chain_id BIGINT NOT NULL,
+	wallet TEXT NOT NULL,
+	user_operation TEXT NOT NULL,
+	status TEXT NOT NULL,
+	submitted_at TIMESTAMPTZ NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL,
+	tx_hash TEXT,
+	actual_gas_cost TEXT,
+	actual_gas_used TEXT,
+	reason TEXT
+);
+
+CREATE INDEX user_operation_chain_status ON user_operation(chain_id, status);
+CREATE INDEX user_operation_wallet_submitted_at ON user_operation(wallet, submitted_at);
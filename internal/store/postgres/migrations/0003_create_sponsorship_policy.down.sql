@@ -0,0 +1 @@
+DROP TABLE sponsorship_policy;
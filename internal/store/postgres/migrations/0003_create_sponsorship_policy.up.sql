@@ -0,0 +1,15 @@
+CREATE TABLE sponsorship_policy (
+	id BIGSERIAL PRIMARY KEY,
+	name TEXT NOT NULL,
+	enabled BOOLEAN NOT NULL,
+	max_gas_cost_per_op TEXT,
+	wallet_daily_gas_budget TEXT,
+	global_spend_cap TEXT,
+	allowed_targets TEXT NOT NULL,
+	allowed_selectors TEXT NOT NULL,
+	active_from TIMESTAMPTZ,
+	active_until TIMESTAMPTZ,
+	validity_seconds BIGINT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL
+);
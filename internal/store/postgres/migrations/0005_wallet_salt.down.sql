@@ -0,0 +1,5 @@
+DROP TABLE wallet_salt;
+DROP INDEX wallet_chain_salt_owner;
+ALTER TABLE wallet DROP COLUMN status;
+ALTER TABLE wallet DROP COLUMN owner;
+ALTER TABLE wallet DROP COLUMN salt;
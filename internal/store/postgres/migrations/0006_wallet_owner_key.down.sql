@@ -0,0 +1,2 @@
+ALTER TABLE wallet DROP COLUMN owner_data_key;
+ALTER TABLE wallet DROP COLUMN owner_key;
@@ -0,0 +1,3 @@
+-- per-wallet owner keys, see model.EncryptedKey
+ALTER TABLE wallet ADD COLUMN owner_key BYTEA;
+ALTER TABLE wallet ADD COLUMN owner_data_key BYTEA;
@@ -0,0 +1 @@
+ALTER TABLE user_operation DROP COLUMN actual_token_cost;
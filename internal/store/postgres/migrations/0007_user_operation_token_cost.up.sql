@@ -0,0 +1,2 @@
+-- paymaster token charged, see model.UserOperationRecord.ActualTokenCost
+ALTER TABLE user_operation ADD COLUMN actual_token_cost TEXT;
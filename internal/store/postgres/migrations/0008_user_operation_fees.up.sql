@@ -0,0 +1,2 @@
+-- fee choice as JSON, see model.Fees
+ALTER TABLE user_operation ADD COLUMN fees TEXT;
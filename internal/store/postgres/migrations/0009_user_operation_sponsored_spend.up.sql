@@ -0,0 +1,11 @@
+-- whether a paymaster sponsored the operation and its maximum gas cost, so
+-- policies sum sponsored spend in SQL; operations recorded before have no
+-- maximum cost and count once their actual cost is known
+ALTER TABLE user_operation ADD COLUMN sponsored BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE user_operation ADD COLUMN max_gas_cost TEXT;
+
+UPDATE user_operation
+SET sponsored = COALESCE(user_operation::jsonb->>'paymaster', '0x0000000000000000000000000000000000000000')
+	<> '0x0000000000000000000000000000000000000000';
+
+CREATE INDEX user_operation_chain_submitted_at ON user_operation(chain_id, submitted_at);
@@ -0,0 +1 @@
+ALTER TABLE sponsorship_policy DROP COLUMN allow_deploys;
@@ -0,0 +1,3 @@
+-- whether a policy with target or selector allowlists sponsors operations
+-- making no call, which only deploy the wallet
+ALTER TABLE sponsorship_policy ADD COLUMN allow_deploys BOOLEAN NOT NULL DEFAULT FALSE;
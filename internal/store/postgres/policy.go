@@ -0,0 +1,206 @@
+package postgres_store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/store"
+)
+
+const policyColumns = `
+	id, name, enabled, max_gas_cost_per_op, wallet_daily_gas_budget,
+	global_spend_cap, allowed_targets, allowed_selectors, active_from,
+	active_until, validity_seconds, allow_deploys, created_at, updated_at
+`
+
+func (s postgresStore) CreatePolicy(policy model.SponsorshipPolicy) (model.SponsorshipPolicy, error) {
+	targets, selectors, err := encodePolicyLists(policy)
+	if err != nil {
+		return model.SponsorshipPolicy{}, err
+	}
+
+	err = s.db.QueryRow(`
+		INSERT INTO sponsorship_policy(
+			name, enabled, max_gas_cost_per_op, wallet_daily_gas_budget,
+			global_spend_cap, allowed_targets, allowed_selectors, active_from,
+			active_until, validity_seconds, allow_deploys, created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`,
+		policy.Name,
+		policy.Enabled,
+		bigToString(policy.MaxGasCostPerOp),
+		bigToString(policy.WalletDailyGasBudget),
+		bigToString(policy.GlobalSpendCap),
+		targets,
+		selectors,
+		policy.ActiveFrom,
+		policy.ActiveUntil,
+		policy.ValiditySeconds,
+		policy.AllowDeploys,
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	).Scan(&policy.ID)
+	if err != nil {
+		return model.SponsorshipPolicy{}, err
+	}
+	return policy, nil
+}
+
+func (s postgresStore) UpdatePolicy(policy model.SponsorshipPolicy) error {
+	targets, selectors, err := encodePolicyLists(policy)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE sponsorship_policy
+		SET name = $1, enabled = $2, max_gas_cost_per_op = $3,
+			wallet_daily_gas_budget = $4, global_spend_cap = $5,
+			allowed_targets = $6, allowed_selectors = $7, active_from = $8,
+			active_until = $9, validity_seconds = $10, allow_deploys = $11,
+			updated_at = $12
+		WHERE id = $13
+	`,
+		policy.Name,
+		policy.Enabled,
+		bigToString(policy.MaxGasCostPerOp),
+		bigToString(policy.WalletDailyGasBudget),
+		bigToString(policy.GlobalSpendCap),
+		targets,
+		selectors,
+		policy.ActiveFrom,
+		policy.ActiveUntil,
+		policy.ValiditySeconds,
+		policy.AllowDeploys,
+		policy.UpdatedAt,
+		policy.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (s postgresStore) DeletePolicy(id int64) error {
+	result, err := s.db.Exec(`
+		DELETE FROM sponsorship_policy WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (s postgresStore) GetPolicy(id int64) (model.SponsorshipPolicy, error) {
+	row := s.db.QueryRow(`
+		SELECT `+policyColumns+` FROM sponsorship_policy
+		WHERE id = $1
+	`, id)
+
+	policy, err := scanPolicy(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.SponsorshipPolicy{}, store.ErrNotFound
+	}
+	return policy, err
+}
+
+func (s postgresStore) GetAllPolicies() ([]model.SponsorshipPolicy, error) {
+	rows, err := s.db.Query(`
+		SELECT ` + policyColumns + ` FROM sponsorship_policy
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []model.SponsorshipPolicy{}
+	for rows.Next() {
+		policy, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, policy)
+	}
+	return result, rows.Err()
+}
+
+func encodePolicyLists(policy model.SponsorshipPolicy) (string, string, error) {
+	targets := policy.AllowedTargets
+	if targets == nil {
+		targets = []model.Address{}
+	}
+	selectors := policy.AllowedSelectors
+	if selectors == nil {
+		selectors = []string{}
+	}
+
+	encodedTargets, err := json.Marshal(targets)
+	if err != nil {
+		return "", "", err
+	}
+	encodedSelectors, err := json.Marshal(selectors)
+	if err != nil {
+		return "", "", err
+	}
+	return string(encodedTargets), string(encodedSelectors), nil
+}
+
+func scanPolicy(row scanner) (model.SponsorshipPolicy, error) {
+	var (
+		policy               model.SponsorshipPolicy
+		maxGasCostPerOp      sql.NullString
+		walletDailyGasBudget sql.NullString
+		globalSpendCap       sql.NullString
+		targets              string
+		selectors            string
+	)
+
+	err := row.Scan(
+		&policy.ID,
+		&policy.Name,
+		&policy.Enabled,
+		&maxGasCostPerOp,
+		&walletDailyGasBudget,
+		&globalSpendCap,
+		&targets,
+		&selectors,
+		&policy.ActiveFrom,
+		&policy.ActiveUntil,
+		&policy.ValiditySeconds,
+		&policy.AllowDeploys,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		return model.SponsorshipPolicy{}, err
+	}
+
+	err = json.Unmarshal([]byte(targets), &policy.AllowedTargets)
+	if err != nil {
+		return model.SponsorshipPolicy{}, err
+	}
+	err = json.Unmarshal([]byte(selectors), &policy.AllowedSelectors)
+	if err != nil {
+		return model.SponsorshipPolicy{}, err
+	}
+
+	policy.MaxGasCostPerOp = stringToBig(maxGasCostPerOp)
+	policy.WalletDailyGasBudget = stringToBig(walletDailyGasBudget)
+	policy.GlobalSpendCap = stringToBig(globalSpendCap)
+	return policy, nil
+}
+
+func expectAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package postgres_store
+
+import (
+	"database/sql"
+	"errors"
+	"math/big"
+	"time"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/store"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+const walletColumns = `chain_id, address, salt, owner, status, owner_key, owner_data_key`
+
+type postgresStore struct {
+	db *sql.DB
+}
+
+// Open connects to dsn with the given pool limits; zero values keep the
+// database/sql defaults.
+func Open(dsn string, pool PoolConfig) (*sql.DB, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if pool.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+
+	err = db.Ping()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func (s postgresStore) AllocateWalletSalt(chainId int64) (*big.Int, error) {
+	var salt int64
+	err := s.db.QueryRow(`
+		INSERT INTO wallet_salt(chain_id, last_salt) VALUES ($1, 1)
+		ON CONFLICT(chain_id) DO UPDATE SET last_salt = wallet_salt.last_salt + 1
+		RETURNING last_salt
+	`, chainId).Scan(&salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return big.NewInt(salt), nil
+}
+
+func (s postgresStore) CreateWallet(wallet model.UserWallet) error {
+	ownerKey, ownerDataKey := encryptedKeyColumns(wallet.OwnerKey)
+
+	_, err := s.db.Exec(`
+		INSERT INTO wallet(`+walletColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		wallet.ChainID,
+		wallet.Sender,
+		bigToString(wallet.Salt),
+		wallet.Owner,
+		string(wallet.Status),
+		ownerKey,
+		ownerDataKey,
+	)
+	return err
+}
+
+func (s postgresStore) UpdateWallet(wallet model.UserWallet) error {
+	result, err := s.db.Exec(`
+		UPDATE wallet SET status = $1
+		WHERE chain_id = $2 AND address = $3
+	`, string(wallet.Status), wallet.ChainID, wallet.Sender)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (s postgresStore) GetAllWallet(chainId int64) ([]model.UserWallet, error) {
+	rows, err := s.db.Query(`
+		SELECT `+walletColumns+` FROM wallet
+		WHERE chain_id = $1
+	`, chainId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := []model.UserWallet{}
+	for rows.Next() {
+		wallet, err := scanWallet(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, wallet)
+	}
+	return result, rows.Err()
+}
+
+func (s postgresStore) GetWallet(chainId int64, sender string) (model.UserWallet, error) {
+	row := s.db.QueryRow(`
+		SELECT `+walletColumns+` FROM wallet
+		WHERE chain_id = $1 AND address = $2
+	`, chainId, sender)
+	wallet, err := scanWallet(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.UserWallet{}, store.ErrNotFound
+	}
+	if err != nil {
+		return model.UserWallet{}, err
+	}
+
+	return wallet, nil
+}
+
+func scanWallet(row scanner) (model.UserWallet, error) {
+	var (
+		wallet       model.UserWallet
+		salt         sql.NullString
+		owner        sql.NullString
+		status       string
+		ownerKey     []byte
+		ownerDataKey []byte
+	)
+
+	err := row.Scan(&wallet.ChainID, &wallet.Sender, &salt, &owner, &status, &ownerKey, &ownerDataKey)
+	if err != nil {
+		return model.UserWallet{}, err
+	}
+
+	wallet.Salt = stringToBig(salt)
+	wallet.Owner = owner.String
+	wallet.Status = model.WalletStatus(status)
+	if ownerKey != nil {
+		wallet.OwnerKey = &model.EncryptedKey{Ciphertext: ownerKey, DataKey: ownerDataKey}
+	}
+	return wallet, nil
+}
+
+func encryptedKeyColumns(key *model.EncryptedKey) ([]byte, []byte) {
+	if key == nil {
+		return nil, nil
+	}
+	return key.Ciphertext, key.DataKey
+}
+
+func NewStore(db *sql.DB) store.Store {
+	return postgresStore{db: db}
+}
@@ -0,0 +1,68 @@
+package postgres_store
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+	"web3-account-abstraction-api/internal/store"
+	"web3-account-abstraction-api/internal/store/migration"
+	"web3-account-abstraction-api/internal/store/storetest"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// TestStore needs a Postgres database it may create schemas in, given by
+// POSTGRES_TEST_DSN; each test runs in a schema of its own.
+func TestStore(t *testing.T) {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set")
+	}
+
+	storetest.Run(t, func(t *testing.T) store.Store {
+		admin, err := Open(dsn, PoolConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer admin.Close()
+
+		schema := fmt.Sprintf("storetest_%d", time.Now().UnixNano())
+		_, err = admin.Exec(`CREATE SCHEMA ` + schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			admin, err := Open(dsn, PoolConfig{})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer admin.Close()
+			_, err = admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+
+		config, err := pgx.ParseConfig(dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// set on every pooled connection
+		config.RuntimeParams["search_path"] = schema
+		db := stdlib.OpenDB(*config)
+		t.Cleanup(func() { db.Close() })
+
+		migrations, err := Migrations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = migration.Up(db, migrations, MigrationLock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return NewStore(db)
+	})
+}
@@ -0,0 +1,18 @@
+package sqlite_store
+
+import (
+	"embed"
+	"io/fs"
+	"web3-account-abstraction-api/internal/store/migration"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+func Migrations() ([]migration.Migration, error) {
+	files, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return migration.Load(files)
+}
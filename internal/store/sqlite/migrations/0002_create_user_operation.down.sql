@@ -0,0 +1,3 @@
+DROP INDEX user_operation_wallet_submitted_at;
+DROP INDEX user_operation_chain_status;
+DROP TABLE user_operation;
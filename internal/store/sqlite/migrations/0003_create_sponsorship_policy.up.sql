@@ -0,0 +1,15 @@
+CREATE TABLE sponsorship_policy (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	enabled BOOLEAN NOT NULL,
+	max_gas_cost_per_op TEXT,
+	wallet_daily_gas_budget TEXT,
+	global_spend_cap TEXT,
+	allowed_targets TEXT NOT NULL,
+	allowed_selectors TEXT NOT NULL,
+	active_from DATETIME,
+	active_until DATETIME,
+	validity_seconds INTEGER NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
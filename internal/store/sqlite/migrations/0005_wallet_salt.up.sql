@@ -0,0 +1,15 @@
+ALTER TABLE wallet ADD COLUMN salt TEXT;
+ALTER TABLE wallet ADD COLUMN owner TEXT;
+ALTER TABLE wallet ADD COLUMN status TEXT NOT NULL DEFAULT 'created';
+
+CREATE UNIQUE INDEX wallet_chain_salt_owner ON wallet(chain_id, salt, owner);
+
+-- last salt handed out per chain; wallets created before this table used
+-- salts 1 to their count
+CREATE TABLE wallet_salt (
+	chain_id INTEGER PRIMARY KEY,
+	last_salt INTEGER NOT NULL
+);
+
+INSERT INTO wallet_salt(chain_id, last_salt)
+SELECT chain_id, count(*) FROM wallet GROUP BY chain_id;
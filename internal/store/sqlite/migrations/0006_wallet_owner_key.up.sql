@@ -0,0 +1,3 @@
+-- per-wallet owner keys, see model.EncryptedKey
+ALTER TABLE wallet ADD COLUMN owner_key BLOB;
+ALTER TABLE wallet ADD COLUMN owner_data_key BLOB;
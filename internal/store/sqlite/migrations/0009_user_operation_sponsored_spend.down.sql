@@ -0,0 +1,3 @@
+DROP INDEX user_operation_chain_submitted_at;
+ALTER TABLE user_operation DROP COLUMN max_gas_cost;
+ALTER TABLE user_operation DROP COLUMN sponsored;
@@ -0,0 +1,17 @@
+-- whether a paymaster sponsored the operation and its maximum gas cost, so
+-- policies sum sponsored spend in SQL; operations recorded before have no
+-- maximum cost and count once their actual cost is known
+ALTER TABLE user_operation ADD COLUMN sponsored BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE user_operation ADD COLUMN max_gas_cost TEXT;
+
+UPDATE user_operation
+SET sponsored = COALESCE(json_extract(user_operation, '$.paymaster'), '0x0000000000000000000000000000000000000000')
+	<> '0x0000000000000000000000000000000000000000';
+
+-- timestamps were stored in the writer's zone; rewrite them in UTC with the
+-- fixed width the store now writes, so they compare as text
+UPDATE user_operation
+SET submitted_at = strftime('%Y-%m-%d %H:%M:%f', submitted_at) || '000000',
+	updated_at = strftime('%Y-%m-%d %H:%M:%f', updated_at) || '000000';
+
+CREATE INDEX user_operation_chain_submitted_at ON user_operation(chain_id, submitted_at);
@@ -0,0 +1,207 @@
+package sqlite_store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/store"
+)
+
+const policyColumns = `
+	id, name, enabled, max_gas_cost_per_op, wallet_daily_gas_budget,
+	global_spend_cap, allowed_targets, allowed_selectors, active_from,
+	active_until, validity_seconds, allow_deploys, created_at, updated_at
+`
+
+func (s sqliteStore) CreatePolicy(policy model.SponsorshipPolicy) (model.SponsorshipPolicy, error) {
+	targets, selectors, err := encodePolicyLists(policy)
+	if err != nil {
+		return model.SponsorshipPolicy{}, err
+	}
+
+	result, err := s.db.Exec(`
+		INSERT INTO sponsorship_policy(
+			name, enabled, max_gas_cost_per_op, wallet_daily_gas_budget,
+			global_spend_cap, allowed_targets, allowed_selectors, active_from,
+			active_until, validity_seconds, allow_deploys, created_at,
+			updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		policy.Name,
+		policy.Enabled,
+		bigToString(policy.MaxGasCostPerOp),
+		bigToString(policy.WalletDailyGasBudget),
+		bigToString(policy.GlobalSpendCap),
+		targets,
+		selectors,
+		policy.ActiveFrom,
+		policy.ActiveUntil,
+		policy.ValiditySeconds,
+		policy.AllowDeploys,
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	)
+	if err != nil {
+		return model.SponsorshipPolicy{}, err
+	}
+
+	policy.ID, err = result.LastInsertId()
+	return policy, err
+}
+
+func (s sqliteStore) UpdatePolicy(policy model.SponsorshipPolicy) error {
+	targets, selectors, err := encodePolicyLists(policy)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE sponsorship_policy
+		SET name = ?, enabled = ?, max_gas_cost_per_op = ?,
+			wallet_daily_gas_budget = ?, global_spend_cap = ?,
+			allowed_targets = ?, allowed_selectors = ?, active_from = ?,
+			active_until = ?, validity_seconds = ?, allow_deploys = ?,
+			updated_at = ?
+		WHERE id = ?
+	`,
+		policy.Name,
+		policy.Enabled,
+		bigToString(policy.MaxGasCostPerOp),
+		bigToString(policy.WalletDailyGasBudget),
+		bigToString(policy.GlobalSpendCap),
+		targets,
+		selectors,
+		policy.ActiveFrom,
+		policy.ActiveUntil,
+		policy.ValiditySeconds,
+		policy.AllowDeploys,
+		policy.UpdatedAt,
+		policy.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (s sqliteStore) DeletePolicy(id int64) error {
+	result, err := s.db.Exec(`
+		DELETE FROM sponsorship_policy WHERE id = ?
+	`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (s sqliteStore) GetPolicy(id int64) (model.SponsorshipPolicy, error) {
+	row := s.db.QueryRow(`
+		SELECT `+policyColumns+` FROM sponsorship_policy
+		WHERE id = ?
+	`, id)
+
+	policy, err := scanPolicy(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.SponsorshipPolicy{}, store.ErrNotFound
+	}
+	return policy, err
+}
+
+func (s sqliteStore) GetAllPolicies() ([]model.SponsorshipPolicy, error) {
+	rows, err := s.db.Query(`
+		SELECT ` + policyColumns + ` FROM sponsorship_policy
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []model.SponsorshipPolicy{}
+	for rows.Next() {
+		policy, err := scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, policy)
+	}
+	return result, rows.Err()
+}
+
+func encodePolicyLists(policy model.SponsorshipPolicy) (string, string, error) {
+	targets := policy.AllowedTargets
+	if targets == nil {
+		targets = []model.Address{}
+	}
+	selectors := policy.AllowedSelectors
+	if selectors == nil {
+		selectors = []string{}
+	}
+
+	encodedTargets, err := json.Marshal(targets)
+	if err != nil {
+		return "", "", err
+	}
+	encodedSelectors, err := json.Marshal(selectors)
+	if err != nil {
+		return "", "", err
+	}
+	return string(encodedTargets), string(encodedSelectors), nil
+}
+
+func scanPolicy(row scanner) (model.SponsorshipPolicy, error) {
+	var (
+		policy               model.SponsorshipPolicy
+		maxGasCostPerOp      sql.NullString
+		walletDailyGasBudget sql.NullString
+		globalSpendCap       sql.NullString
+		targets              string
+		selectors            string
+	)
+
+	err := row.Scan(
+		&policy.ID,
+		&policy.Name,
+		&policy.Enabled,
+		&maxGasCostPerOp,
+		&walletDailyGasBudget,
+		&globalSpendCap,
+		&targets,
+		&selectors,
+		&policy.ActiveFrom,
+		&policy.ActiveUntil,
+		&policy.ValiditySeconds,
+		&policy.AllowDeploys,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		return model.SponsorshipPolicy{}, err
+	}
+
+	err = json.Unmarshal([]byte(targets), &policy.AllowedTargets)
+	if err != nil {
+		return model.SponsorshipPolicy{}, err
+	}
+	err = json.Unmarshal([]byte(selectors), &policy.AllowedSelectors)
+	if err != nil {
+		return model.SponsorshipPolicy{}, err
+	}
+
+	policy.MaxGasCostPerOp = stringToBig(maxGasCostPerOp)
+	policy.WalletDailyGasBudget = stringToBig(walletDailyGasBudget)
+	policy.GlobalSpendCap = stringToBig(globalSpendCap)
+	return policy, nil
+}
+
+func expectAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
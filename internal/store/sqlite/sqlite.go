@@ -2,80 +2,137 @@ package sqlite_store
 
 import (
 	"database/sql"
+	"errors"
+	"math/big"
 	"web3-account-abstraction-api/internal/model"
 	"web3-account-abstraction-api/internal/store"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+const walletColumns = `chain_id, address, salt, owner, status, owner_key, owner_data_key`
+
 type sqliteStore struct {
 	db *sql.DB
 }
 
-func (s sqliteStore) CountWallet() (int, error) {
-	row := s.db.QueryRow(`
-		SELECT count(address) FROM wallet
-	`)
-	var count int
-	err := row.Scan(&count)
+func (s sqliteStore) AllocateWalletSalt(chainId int64) (*big.Int, error) {
+	var salt int64
+	err := s.db.QueryRow(`
+		INSERT INTO wallet_salt(chain_id, last_salt) VALUES (?, 1)
+		ON CONFLICT(chain_id) DO UPDATE SET last_salt = last_salt + 1
+		RETURNING last_salt
+	`, chainId).Scan(&salt)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return count, nil
+	return big.NewInt(salt), nil
 }
 
 func (s sqliteStore) CreateWallet(wallet model.UserWallet) error {
+	ownerKey, ownerDataKey := encryptedKeyColumns(wallet.OwnerKey)
+
 	stmt, err := s.db.Prepare(`
-		INSERT INTO wallet(address) VALUES (?)
+		INSERT INTO wallet(` + walletColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(wallet.Sender)
+	_, err = stmt.Exec(
+		wallet.ChainID,
+		wallet.Sender,
+		bigToString(wallet.Salt),
+		wallet.Owner,
+		string(wallet.Status),
+		ownerKey,
+		ownerDataKey,
+	)
 	return err
 }
 
-func (s sqliteStore) GetAllWallet() ([]model.UserWallet, error) {
+func (s sqliteStore) UpdateWallet(wallet model.UserWallet) error {
+	result, err := s.db.Exec(`
+		UPDATE wallet SET status = ?
+		WHERE chain_id = ? AND address = ?
+	`, string(wallet.Status), wallet.ChainID, wallet.Sender)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (s sqliteStore) GetAllWallet(chainId int64) ([]model.UserWallet, error) {
 	rows, err := s.db.Query(`
-		SELECT address FROM wallet
-	`)
+		SELECT `+walletColumns+` FROM wallet
+		WHERE chain_id = ?
+	`, chainId)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	result := []model.UserWallet{}
 	for rows.Next() {
-		var address string
-		err = rows.Scan(&address)
+		wallet, err := scanWallet(rows)
 		if err != nil {
 			return nil, err
 		}
-		result = append(result, model.UserWallet{Sender: address})
+		result = append(result, wallet)
 	}
-	return result, nil
+	return result, rows.Err()
 }
 
-func (s sqliteStore) GetWallet(sender string) (model.UserWallet, error) {
+func (s sqliteStore) GetWallet(chainId int64, sender string) (model.UserWallet, error) {
 	stmt, err := s.db.Prepare(`
-		SELECT address FROM wallet
-		WHERE address = ?
+		SELECT ` + walletColumns + ` FROM wallet
+		WHERE chain_id = ? AND address = ?
 	`)
 	if err != nil {
 		return model.UserWallet{}, err
 	}
 	defer stmt.Close()
-	var addr string
-	err = stmt.QueryRow(sender).Scan(&addr)
+	wallet, err := scanWallet(stmt.QueryRow(chainId, sender))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.UserWallet{}, store.ErrNotFound
+	}
 	if err != nil {
 		return model.UserWallet{}, err
 	}
 
-	return model.UserWallet{
-		Sender: addr,
-	}, nil
+	return wallet, nil
+}
+
+func scanWallet(row scanner) (model.UserWallet, error) {
+	var (
+		wallet       model.UserWallet
+		salt         sql.NullString
+		owner        sql.NullString
+		status       string
+		ownerKey     []byte
+		ownerDataKey []byte
+	)
+
+	err := row.Scan(&wallet.ChainID, &wallet.Sender, &salt, &owner, &status, &ownerKey, &ownerDataKey)
+	if err != nil {
+		return model.UserWallet{}, err
+	}
+
+	wallet.Salt = stringToBig(salt)
+	wallet.Owner = owner.String
+	wallet.Status = model.WalletStatus(status)
+	if ownerKey != nil {
+		wallet.OwnerKey = &model.EncryptedKey{Ciphertext: ownerKey, DataKey: ownerDataKey}
+	}
+	return wallet, nil
+}
+
+func encryptedKeyColumns(key *model.EncryptedKey) ([]byte, []byte) {
+	if key == nil {
+		return nil, nil
+	}
+	return key.Ciphertext, key.DataKey
 }
 
 func NewStore(db *sql.DB) store.Store {
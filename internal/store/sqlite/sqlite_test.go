@@ -0,0 +1,30 @@
+package sqlite_store
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"web3-account-abstraction-api/internal/store"
+	"web3-account-abstraction-api/internal/store/migration"
+	"web3-account-abstraction-api/internal/store/storetest"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "store.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		migrations, err := Migrations()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = migration.Up(db, migrations, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return NewStore(db)
+	})
+}
@@ -0,0 +1,244 @@
+package sqlite_store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/store"
+	"web3-account-abstraction-api/utils"
+)
+
+const userOperationColumns = `
+	hash, chain_id, wallet, user_operation, status, submitted_at, updated_at,
+	tx_hash, actual_gas_cost, actual_gas_used, reason, actual_token_cost, fees
+`
+
+// spentGasCost is what an operation counts towards sponsored spend: nothing
+// when dropped, its actual cost once known and its maximum cost until then.
+const spentGasCost = `
+	CASE WHEN status = 'dropped' THEN '0'
+	ELSE COALESCE(actual_gas_cost, max_gas_cost, '0') END
+`
+
+func (s sqliteStore) CreateUserOperation(record model.UserOperationRecord) error {
+	userOp, err := json.Marshal(record.UserOperation)
+	if err != nil {
+		return err
+	}
+	fees, err := feesToString(record.Fees)
+	if err != nil {
+		return err
+	}
+	paymaster := record.UserOperation.Paymaster
+	sponsored := paymaster != nil && !utils.IsZeroAddress(*paymaster)
+
+	_, err = s.db.Exec(`
+		INSERT INTO user_operation(`+userOperationColumns+`, sponsored, max_gas_cost)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		record.Hash,
+		record.ChainID,
+		record.Wallet,
+		string(userOp),
+		string(record.Status),
+		formatTime(record.SubmittedAt),
+		formatTime(record.UpdatedAt),
+		record.TxHash,
+		bigToString(record.ActualGasCost),
+		bigToString(record.ActualGasUsed),
+		record.Reason,
+		bigToString(record.ActualTokenCost),
+		fees,
+		sponsored,
+		bigToString(record.UserOperation.MaxGasCost()),
+	)
+	return err
+}
+
+func (s sqliteStore) UpdateUserOperation(record model.UserOperationRecord) error {
+	result, err := s.db.Exec(`
+		UPDATE user_operation
+		SET status = ?, updated_at = ?, tx_hash = ?,
+			actual_gas_cost = ?, actual_gas_used = ?, reason = ?,
+			actual_token_cost = ?
+		WHERE hash = ?
+	`,
+		string(record.Status),
+		formatTime(record.UpdatedAt),
+		record.TxHash,
+		bigToString(record.ActualGasCost),
+		bigToString(record.ActualGasUsed),
+		record.Reason,
+		bigToString(record.ActualTokenCost),
+		record.Hash,
+	)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+func (s sqliteStore) GetUserOperation(hash string) (model.UserOperationRecord, error) {
+	row := s.db.QueryRow(`
+		SELECT `+userOperationColumns+` FROM user_operation
+		WHERE hash = ?
+	`, hash)
+
+	record, err := scanUserOperation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.UserOperationRecord{}, store.ErrNotFound
+	}
+	return record, err
+}
+
+func (s sqliteStore) GetUserOperationsByStatus(chainId int64, status model.UserOperationStatus) ([]model.UserOperationRecord, error) {
+	rows, err := s.db.Query(`
+		SELECT `+userOperationColumns+` FROM user_operation
+		WHERE chain_id = ? AND status = ?
+		ORDER BY submitted_at
+	`, chainId, string(status))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []model.UserOperationRecord{}
+	for rows.Next() {
+		record, err := scanUserOperation(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, rows.Err()
+}
+
+// SponsoredGasCost sums in Go: SQLite has no integer wider than 64 bits and
+// its SUM and TOTAL of wei lose precision, so the decimal costs are read and
+// added as big integers.
+func (s sqliteStore) SponsoredGasCost(chainId int64, wallet string, since time.Time) (*big.Int, error) {
+	rows, err := s.db.Query(`
+		SELECT `+spentGasCost+` FROM user_operation
+		WHERE chain_id = ? AND sponsored AND (? = '' OR wallet = ?) AND submitted_at >= ?
+	`, chainId, wallet, wallet, formatTime(since))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	total := new(big.Int)
+	for rows.Next() {
+		var cost string
+		err = rows.Scan(&cost)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := new(big.Int).SetString(cost, 10)
+		if !ok {
+			return nil, fmt.Errorf("gas cost %q is not an integer", cost)
+		}
+		total.Add(total, value)
+	}
+	return total, rows.Err()
+}
+
+// timeFormat is how user operation timestamps are stored: in UTC and with a
+// fixed width, so they compare and sort as text. go-sqlite3 reads it back as
+// a DATETIME.
+const timeFormat = "2006-01-02 15:04:05.000000000"
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUserOperation(row scanner) (model.UserOperationRecord, error) {
+	var (
+		record          model.UserOperationRecord
+		userOp          string
+		status          string
+		actualGasCost   sql.NullString
+		actualGasUsed   sql.NullString
+		actualTokenCost sql.NullString
+		fees            sql.NullString
+		submittedAt     time.Time
+		updatedAt       time.Time
+	)
+
+	err := row.Scan(
+		&record.Hash,
+		&record.ChainID,
+		&record.Wallet,
+		&userOp,
+		&status,
+		&submittedAt,
+		&updatedAt,
+		&record.TxHash,
+		&actualGasCost,
+		&actualGasUsed,
+		&record.Reason,
+		&actualTokenCost,
+		&fees,
+	)
+	if err != nil {
+		return model.UserOperationRecord{}, err
+	}
+
+	err = json.Unmarshal([]byte(userOp), &record.UserOperation)
+	if err != nil {
+		return model.UserOperationRecord{}, err
+	}
+
+	record.Status = model.UserOperationStatus(status)
+	record.SubmittedAt = submittedAt
+	record.UpdatedAt = updatedAt
+	record.ActualGasCost = stringToBig(actualGasCost)
+	record.ActualGasUsed = stringToBig(actualGasUsed)
+	record.ActualTokenCost = stringToBig(actualTokenCost)
+	if fees.Valid {
+		record.Fees = &model.Fees{}
+		err = json.Unmarshal([]byte(fees.String), record.Fees)
+		if err != nil {
+			return model.UserOperationRecord{}, err
+		}
+	}
+	return record, nil
+}
+
+func feesToString(fees *model.Fees) (*string, error) {
+	if fees == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(fees)
+	if err != nil {
+		return nil, err
+	}
+	s := string(data)
+	return &s, nil
+}
+
+func bigToString(value *big.Int) *string {
+	if value == nil {
+		return nil
+	}
+	s := value.String()
+	return &s
+}
+
+func stringToBig(value sql.NullString) *big.Int {
+	if !value.Valid {
+		return nil
+	}
+	result, ok := new(big.Int).SetString(value.String, 10)
+	if !ok {
+		return nil
+	}
+	return result
+}
@@ -1,10 +1,38 @@
 package store
 
-import "web3-account-abstraction-api/internal/model"
+import (
+	"math/big"
+	"time"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/model"
+)
+
+var (
+	ErrNotFound = apperr.New(apperr.KindNotFound, "not_found", "not found")
+)
 
 type Store interface {
-	CountWallet() (int, error)
+	// AllocateWalletSalt atomically hands out the next unused salt of the
+	// chain; concurrent callers never receive the same salt.
+	AllocateWalletSalt(chainId int64) (*big.Int, error)
 	CreateWallet(model.UserWallet) error
-	GetWallet(sender string) (model.UserWallet, error)
-	GetAllWallet() ([]model.UserWallet, error)
+	UpdateWallet(model.UserWallet) error
+	GetWallet(chainId int64, sender string) (model.UserWallet, error)
+	GetAllWallet(chainId int64) ([]model.UserWallet, error)
+
+	CreateUserOperation(model.UserOperationRecord) error
+	UpdateUserOperation(model.UserOperationRecord) error
+	GetUserOperation(hash string) (model.UserOperationRecord, error)
+	GetUserOperationsByStatus(chainId int64, status model.UserOperationStatus) ([]model.UserOperationRecord, error)
+	// SponsoredGasCost sums the gas cost of the chain's paymaster sponsored
+	// operations submitted at or after since: the actual cost of those
+	// included or failed, the maximum cost of pending ones and nothing for
+	// dropped ones. An empty wallet sums every wallet.
+	SponsoredGasCost(chainId int64, wallet string, since time.Time) (*big.Int, error)
+
+	CreatePolicy(model.SponsorshipPolicy) (model.SponsorshipPolicy, error)
+	UpdatePolicy(model.SponsorshipPolicy) error
+	DeletePolicy(id int64) error
+	GetPolicy(id int64) (model.SponsorshipPolicy, error)
+	GetAllPolicies() ([]model.SponsorshipPolicy, error)
 }
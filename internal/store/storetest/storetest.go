@@ -0,0 +1,389 @@
+// Package storetest is the conformance suite every store.Store
+// implementation runs, so the SQLite and Postgres stores behave the same.
+package storetest
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/store"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Run runs the suite; newStore returns an empty, migrated store and is
+// called once per test.
+func Run(t *testing.T, newStore func(t *testing.T) store.Store) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, s store.Store)
+	}{
+		{"AllocateWalletSalt", testAllocateWalletSalt},
+		{"Wallet", testWallet},
+		{"UserOperation", testUserOperation},
+		{"SponsoredGasCost", testSponsoredGasCost},
+		{"Policy", testPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newStore(t))
+		})
+	}
+}
+
+// timestamps are compared at second precision, which every store keeps
+var now = time.Now().UTC().Truncate(time.Second)
+
+func testAllocateWalletSalt(t *testing.T, s store.Store) {
+	for _, want := range []int64{1, 2, 3} {
+		salt, err := s.AllocateWalletSalt(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if salt.Int64() != want {
+			t.Fatalf("salt %d, want %d", salt, want)
+		}
+	}
+
+	// salts are counted per chain
+	salt, err := s.AllocateWalletSalt(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if salt.Int64() != 1 {
+		t.Fatalf("first salt of another chain %d, want 1", salt)
+	}
+}
+
+func testWallet(t *testing.T, s store.Store) {
+	wallet := model.UserWallet{
+		ChainID:  1,
+		Sender:   "0x0000000000000000000000000000000000000001",
+		Salt:     big.NewInt(7),
+		Owner:    "0x00000000000000000000000000000000000000aa",
+		Status:   model.WalletStatusReserved,
+		OwnerKey: &model.EncryptedKey{Ciphertext: []byte{1, 2}, DataKey: []byte{3}},
+	}
+	legacy := model.UserWallet{
+		ChainID: 1,
+		Sender:  "0x0000000000000000000000000000000000000002",
+		Status:  model.WalletStatusCreated,
+	}
+	otherChain := wallet
+	otherChain.ChainID = 2
+	for _, w := range []model.UserWallet{wallet, legacy, otherChain} {
+		err := s.CreateWallet(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.CreateWallet(wallet) == nil {
+		t.Error("created the same wallet twice")
+	}
+
+	got, err := s.GetWallet(1, wallet.Sender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Salt == nil || got.Salt.Cmp(wallet.Salt) != 0 || got.Owner != wallet.Owner || got.Status != wallet.Status {
+		t.Errorf("got wallet %+v, want %+v", got, wallet)
+	}
+	if got.OwnerKey == nil || string(got.OwnerKey.Ciphertext) != "\x01\x02" || string(got.OwnerKey.DataKey) != "\x03" {
+		t.Errorf("got owner key %+v, want %+v", got.OwnerKey, wallet.OwnerKey)
+	}
+
+	got, err = s.GetWallet(1, legacy.Sender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Salt != nil || got.Owner != "" || got.OwnerKey != nil {
+		t.Errorf("got wallet %+v, want no salt, owner or key", got)
+	}
+
+	wallet.Status = model.WalletStatusCreated
+	err = s.UpdateWallet(wallet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = s.GetWallet(1, wallet.Sender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != model.WalletStatusCreated {
+		t.Errorf("status %q after update, want %q", got.Status, model.WalletStatusCreated)
+	}
+
+	all, err := s.GetAllWallet(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Errorf("%d wallets on chain 1, want 2", len(all))
+	}
+
+	_, err = s.GetWallet(3, wallet.Sender)
+	expectNotFound(t, "GetWallet", err)
+	err = s.UpdateWallet(model.UserWallet{ChainID: 3, Sender: wallet.Sender})
+	expectNotFound(t, "UpdateWallet", err)
+}
+
+func testUserOperation(t *testing.T, s store.Store) {
+	record := userOperationRecord("0x01", "0x0000000000000000000000000000000000000001", now)
+	record.Fees = &model.Fees{
+		Tier:                 model.FeeTier("fast"),
+		Strategy:             "oracle",
+		MaxFeePerGas:         big.NewInt(2_000),
+		MaxPriorityFeePerGas: big.NewInt(100),
+	}
+	err := s.CreateUserOperation(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.GetUserOperation(record.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ChainID != record.ChainID || got.Wallet != record.Wallet || got.Status != record.Status || !got.SubmittedAt.Equal(record.SubmittedAt) {
+		t.Errorf("got %+v, want %+v", got, record)
+	}
+	if got.UserOperation.Sender != record.UserOperation.Sender || got.UserOperation.Nonce.Cmp(record.UserOperation.Nonce) != 0 {
+		t.Errorf("got operation %+v, want %+v", got.UserOperation, record.UserOperation)
+	}
+	if got.Fees == nil || got.Fees.MaxFeePerGas.Cmp(record.Fees.MaxFeePerGas) != 0 {
+		t.Errorf("got fees %+v, want %+v", got.Fees, record.Fees)
+	}
+	if got.TxHash != nil || got.ActualGasCost != nil || got.ActualTokenCost != nil {
+		t.Errorf("got outcome of a pending operation %+v", got)
+	}
+
+	txHash := "0xabc"
+	record.Status = model.UserOperationStatusIncluded
+	record.UpdatedAt = now.Add(time.Minute)
+	record.TxHash = &txHash
+	record.ActualGasCost = big.NewInt(21_000_000)
+	record.ActualGasUsed = big.NewInt(21_000)
+	record.ActualTokenCost = big.NewInt(42)
+	err = s.UpdateUserOperation(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = s.GetUserOperation(record.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != record.Status || got.TxHash == nil || *got.TxHash != txHash || !got.UpdatedAt.Equal(record.UpdatedAt) ||
+		got.ActualGasCost.Cmp(record.ActualGasCost) != 0 || got.ActualTokenCost.Cmp(record.ActualTokenCost) != 0 {
+		t.Errorf("got %+v after update, want %+v", got, record)
+	}
+
+	pending := userOperationRecord("0x02", record.Wallet, now.Add(time.Second))
+	err = s.CreateUserOperation(pending)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byStatus, err := s.GetUserOperationsByStatus(1, model.UserOperationStatusPending)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byStatus) != 1 || byStatus[0].Hash != pending.Hash {
+		t.Errorf("got pending operations %v, want only %s", hashes(byStatus), pending.Hash)
+	}
+	byStatus, err = s.GetUserOperationsByStatus(2, model.UserOperationStatusPending)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byStatus) != 0 {
+		t.Errorf("got pending operations %v of another chain", hashes(byStatus))
+	}
+
+	_, err = s.GetUserOperation("0xff")
+	expectNotFound(t, "GetUserOperation", err)
+	err = s.UpdateUserOperation(userOperationRecord("0xff", record.Wallet, now))
+	expectNotFound(t, "UpdateUserOperation", err)
+}
+
+func testSponsoredGasCost(t *testing.T, s store.Store) {
+	walletA := "0x0000000000000000000000000000000000000001"
+	walletB := "0x0000000000000000000000000000000000000002"
+	sponsored := func(hash string, chainId int64, wallet string, submittedAt time.Time, status model.UserOperationStatus, actual int64) model.UserOperationRecord {
+		record := userOperationRecord(hash, wallet, submittedAt)
+		record.ChainID = chainId
+		record.Status = status
+		paymaster := common.HexToAddress("0xaa")
+		record.UserOperation.Paymaster = &paymaster
+		// 700k gas at 10 wei
+		record.UserOperation.CallGasLimit = big.NewInt(100_000)
+		record.UserOperation.VerificationGasLimit = big.NewInt(200_000)
+		record.UserOperation.PreVerificationGas = big.NewInt(50_000)
+		record.UserOperation.PaymasterVerificationGasLimit = big.NewInt(300_000)
+		record.UserOperation.PaymasterPostOpGasLimit = big.NewInt(50_000)
+		record.UserOperation.MaxFeePerGas = big.NewInt(10)
+		if actual != 0 {
+			record.ActualGasCost = big.NewInt(actual)
+		}
+		return record
+	}
+	// far east of UTC, so its local time reads a day later
+	kiritimati := time.FixedZone("LINT", 14*60*60)
+
+	selfPaid := userOperationRecord("0x06", walletA, now)
+	selfPaid.ActualGasCost = big.NewInt(1 << 40)
+	otherChain := sponsored("0x08", 2, walletA, now, model.UserOperationStatusIncluded, 0)
+	otherChain.ActualGasCost = new(big.Int).Exp(big.NewInt(10), big.NewInt(21), nil)
+	records := []model.UserOperationRecord{
+		sponsored("0x01", 1, walletA, now.Add(-time.Hour), model.UserOperationStatusPending, 0),
+		sponsored("0x02", 1, walletA, now.Add(-2*time.Hour), model.UserOperationStatusIncluded, 1_000),
+		sponsored("0x03", 1, walletA, now.Add(-time.Hour), model.UserOperationStatusDropped, 0),
+		sponsored("0x04", 1, walletA, now.Add(-48*time.Hour), model.UserOperationStatusPending, 0),
+		sponsored("0x05", 1, walletA, now.Add(-25*time.Hour).In(kiritimati), model.UserOperationStatusIncluded, 7),
+		selfPaid,
+		sponsored("0x07", 1, walletB, now, model.UserOperationStatusIncluded, 500),
+		otherChain,
+		// beyond the precision of a double once added to 0x08
+		sponsored("0x09", 2, walletA, now, model.UserOperationStatusIncluded, 1),
+	}
+	for _, record := range records {
+		err := s.CreateUserOperation(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the reconciler records the outcome after the operation is created
+		if record.Status != model.UserOperationStatusPending {
+			err = s.UpdateUserOperation(record)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	since := now.Add(-24 * time.Hour).In(time.FixedZone("EST", -5*60*60))
+	tests := []struct {
+		chainId int64
+		wallet  string
+		since   time.Time
+		want    string
+	}{
+		{1, walletA, since, "7001000"},
+		{1, walletB, since, "500"},
+		{1, "", since, "7001500"},
+		{1, "", time.Time{}, "14001507"},
+		{2, "", time.Time{}, "1000000000000000000001"},
+		{3, "", time.Time{}, "0"},
+	}
+	for _, tt := range tests {
+		got, err := s.SponsoredGasCost(tt.chainId, tt.wallet, tt.since)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != tt.want {
+			t.Errorf("chain %d, wallet %q, since %s: got %s, want %s", tt.chainId, tt.wallet, tt.since, got, tt.want)
+		}
+	}
+}
+
+func testPolicy(t *testing.T, s store.Store) {
+	from := now.Add(-time.Hour)
+	policy := model.SponsorshipPolicy{
+		Name:             "default",
+		Enabled:          true,
+		MaxGasCostPerOp:  big.NewInt(1_000_000),
+		GlobalSpendCap:   new(big.Int).Lsh(big.NewInt(1), 80),
+		AllowedTargets:   []model.Address{common.HexToAddress("0x01")},
+		AllowedSelectors: []string{"0xa9059cbb"},
+		AllowDeploys:     true,
+		ActiveFrom:       &from,
+		ValiditySeconds:  600,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	created, err := s.CreatePolicy(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == 0 {
+		t.Fatal("created policy has no ID")
+	}
+	second, err := s.CreatePolicy(model.SponsorshipPolicy{Name: "second", CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.GetPolicy(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != policy.Name || !got.Enabled || got.GlobalSpendCap.Cmp(policy.GlobalSpendCap) != 0 || got.WalletDailyGasBudget != nil ||
+		len(got.AllowedTargets) != 1 || got.AllowedTargets[0] != policy.AllowedTargets[0] ||
+		len(got.AllowedSelectors) != 1 || got.AllowedSelectors[0] != policy.AllowedSelectors[0] || !got.AllowDeploys ||
+		got.ActiveFrom == nil || !got.ActiveFrom.Equal(from) || got.ActiveUntil != nil || got.ValiditySeconds != 600 {
+		t.Errorf("got policy %+v, want %+v", got, policy)
+	}
+
+	got.Enabled = false
+	got.AllowedTargets = nil
+	got.AllowDeploys = false
+	got.UpdatedAt = now.Add(time.Minute)
+	err = s.UpdatePolicy(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = s.GetPolicy(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Enabled || len(got.AllowedTargets) != 0 || got.AllowDeploys || !got.UpdatedAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("got policy %+v after update", got)
+	}
+
+	all, err := s.GetAllPolicies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].ID != created.ID || all[1].ID != second.ID {
+		t.Errorf("got %d policies, want %d and %d in order", len(all), created.ID, second.ID)
+	}
+
+	err = s.DeletePolicy(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.GetPolicy(created.ID)
+	expectNotFound(t, "GetPolicy", err)
+	err = s.UpdatePolicy(got)
+	expectNotFound(t, "UpdatePolicy", err)
+	err = s.DeletePolicy(created.ID)
+	expectNotFound(t, "DeletePolicy", err)
+}
+
+func userOperationRecord(hash string, wallet string, submittedAt time.Time) model.UserOperationRecord {
+	return model.UserOperationRecord{
+		Hash:    hash,
+		ChainID: 1,
+		Wallet:  wallet,
+		UserOperation: model.UserOperation{
+			Sender: common.HexToAddress(wallet),
+			Nonce:  big.NewInt(3),
+		},
+		Status:      model.UserOperationStatusPending,
+		SubmittedAt: submittedAt,
+		UpdatedAt:   submittedAt,
+	}
+}
+
+func hashes(records []model.UserOperationRecord) []string {
+	result := make([]string, len(records))
+	for i, record := range records {
+		result[i] = record.Hash
+	}
+	return result
+}
+
+func expectNotFound(t *testing.T, call string, err error) {
+	t.Helper()
+	if !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("%s: got %v, want %v", call, err, store.ErrNotFound)
+	}
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"math/big"
+	"web3-account-abstraction-api/generated/abi/account"
+	"web3-account-abstraction-api/generated/abi/entrypoint"
+	"web3-account-abstraction-api/generated/abi/erc20"
+	"web3-account-abstraction-api/generated/abi/paymaster"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/bundler"
+	"web3-account-abstraction-api/internal/revert"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrNotExecuteCall = apperr.New(apperr.KindValidation, "not_execute_call", "call data is not an execute call")
+)
+
+type Call struct {
+	Target common.Address `json:"target"`
+	Value  *big.Int       `json:"value"`
+	Data   hexutil.Bytes  `json:"data"`
+}
+
+type DecodedLog struct {
+	Address common.Address         `json:"address"`
+	Event   string                 `json:"event,omitempty"`
+	Args    map[string]interface{} `json:"args,omitempty"`
+	Topics  []common.Hash          `json:"topics"`
+	Data    hexutil.Bytes          `json:"data"`
+}
+
+type CallResult struct {
+	Call
+	Events []DecodedLog `json:"events"`
+}
+
+type CallsResult struct {
+	UserOpHash string  `json:"userOpHash"`
+	Included   bool    `json:"included"`
+	Success    bool    `json:"success"`
+	Reason     *string `json:"reason"`
+	// why a failed operation reverted, decoded
+	RevertReason *revert.Error `json:"revertReason,omitempty"`
+	Call         CallResult    `json:"call"`
+	Other        []DecodedLog  `json:"otherEvents"`
+}
+
+// DecodeCall extracts the inner call of an Account.execute call data.
+func DecodeCall(callData []byte) (Call, error) {
+	accountAbi, err := account.AccountMetaData.GetAbi()
+	if err != nil {
+		return Call{}, err
+	}
+	if len(callData) < 4 {
+		return Call{}, ErrNotExecuteCall
+	}
+
+	method, err := accountAbi.MethodById(callData[:4])
+	if err != nil || method.Name != "execute" {
+		return Call{}, ErrNotExecuteCall
+	}
+	args, err := method.Inputs.Unpack(callData[4:])
+	if err != nil {
+		return Call{}, err
+	}
+	return Call{
+		Target: args[0].(common.Address),
+		Value:  args[1].(*big.Int),
+		Data:   args[2].([]byte),
+	}, nil
+}
+
+// GetUserOperationCalls decodes the call of a stored user operation and,
+// once it is included, attributes the receipt logs to it.
+func (u *Usecase) GetUserOperationCalls(hash string) (CallsResult, error) {
+	record, err := u.GetUserOperationStatus(hash)
+	if err != nil {
+		return CallsResult{}, err
+	}
+
+	call, err := DecodeCall(record.UserOperation.CallData)
+	if err != nil {
+		return CallsResult{}, err
+	}
+
+	result := CallsResult{
+		UserOpHash: hash,
+		Call:       CallResult{Call: call, Events: []DecodedLog{}},
+		Other:      []DecodedLog{},
+	}
+
+	receipt, err := u.bundler.GetUserOperationReceipt(hash)
+	if err != nil {
+		return CallsResult{}, err
+	}
+	if receipt == nil {
+		return result, nil
+	}
+
+	result.Included = true
+	result.Success = receipt.Success
+	result.Reason = receipt.Reason
+	if !receipt.Success {
+		result.RevertReason = revertReason(receipt)
+	}
+	attributeLogs(&result, receipt.Logs)
+
+	return result, nil
+}
+
+// attributeLogs assigns the logs emitted by the call's target to the call
+// and the others (EntryPoint, paymaster, ...) to Other.
+func attributeLogs(result *CallsResult, logs []types.Log) {
+	for _, log := range logs {
+		decoded := decodeLog(log)
+		if log.Address == result.Call.Target {
+			result.Call.Events = append(result.Call.Events, decoded)
+		} else {
+			result.Other = append(result.Other, decoded)
+		}
+	}
+}
+
+// revertReason decodes why an included operation failed: from the
+// UserOperationRevertReason event the EntryPoint emits, else from the
+// receipt's reason.
+func revertReason(receipt *bundler.UserOperationReceipt) *revert.Error {
+	entryPointAbi, err := entrypoint.EntryPointMetaData.GetAbi()
+	if err != nil {
+		return nil
+	}
+	event := entryPointAbi.Events["UserOperationRevertReason"]
+	for _, log := range receipt.Logs {
+		if len(log.Topics) < 2 || log.Topics[0] != event.ID || log.Topics[1] != receipt.UserOpHash {
+			continue
+		}
+		args := map[string]interface{}{}
+		err = entryPointAbi.UnpackIntoMap(args, event.Name, log.Data)
+		if err != nil {
+			continue
+		}
+		if data, ok := args["revertReason"].([]byte); ok {
+			return revert.Decode(data)
+		}
+	}
+	if receipt.Reason != nil {
+		return revert.FromReason(*receipt.Reason)
+	}
+	return nil
+}
+
+var knownEventAbis = func() []*abi.ABI {
+	result := []*abi.ABI{}
+	for _, metadata := range []interface{ GetAbi() (*abi.ABI, error) }{
+		erc20.ERC20MetaData,
+		paymaster.PaymasterMetaData,
+		entrypoint.EntryPointMetaData,
+		account.AccountMetaData,
+	} {
+		parsed, err := metadata.GetAbi()
+		if err == nil {
+			result = append(result, parsed)
+		}
+	}
+	return result
+}()
+
+func decodeLog(log types.Log) DecodedLog {
+	decoded := DecodedLog{
+		Address: log.Address,
+		Topics:  log.Topics,
+		Data:    log.Data,
+	}
+	if len(log.Topics) == 0 {
+		return decoded
+	}
+
+	for _, parsed := range knownEventAbis {
+		event, err := parsed.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+
+		args := map[string]interface{}{}
+		err = parsed.UnpackIntoMap(args, event.Name, log.Data)
+		if err != nil {
+			continue
+		}
+		indexed := abi.Arguments{}
+		for _, input := range event.Inputs {
+			if input.Indexed {
+				indexed = append(indexed, input)
+			}
+		}
+		err = abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:])
+		if err != nil {
+			continue
+		}
+
+		decoded.Event = event.Name
+		decoded.Args = args
+		return decoded
+	}
+	return decoded
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"fmt"
+	"math/big"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInsufficientPrefund = apperr.New(apperr.KindValidation, "insufficient_prefund", "wallet deposit and balance do not cover the maximum gas cost")
+
+// WalletDeposit is a wallet's EntryPoint deposit along with its own balance,
+// which self-paid operations draw on when the deposit is short.
+type WalletDeposit struct {
+	model.DepositInfo
+	Balance *big.Int `json:"balance"`
+}
+
+func (u *Usecase) GetWalletDeposit(sender common.Address) (WalletDeposit, error) {
+	info, err := u.contracts.GetDepositInfo(sender)
+	if err != nil {
+		return WalletDeposit{}, err
+	}
+	balance, err := u.contracts.GetETHBalance(sender)
+	if err != nil {
+		return WalletDeposit{}, err
+	}
+	return WalletDeposit{DepositInfo: info, Balance: balance}, nil
+}
+
+// FundWalletDeposit adds amount wei to the wallet's EntryPoint deposit from
+// the default owner's balance.
+func (u *Usecase) FundWalletDeposit(sender common.Address, amount *big.Int) (common.Hash, error) {
+	tx, err := u.contracts.FundDeposit(sender, amount)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// WithdrawWalletDeposit withdraws amount wei of the wallet's EntryPoint
+// deposit to the given address through a self-paid user operation running
+// EntryPoint.withdrawTo, and returns its hash. Its gas is taken from the
+// deposit first, so amount has to leave room for it.
+func (u *Usecase) WithdrawWalletDeposit(sender common.Address, to common.Address, amount *big.Int) (string, error) {
+	data, err := u.contracts.GetWithdrawToCallData(to, amount)
+	if err != nil {
+		return "", err
+	}
+	call := Call{Target: u.contracts.EntryPointAddress, Value: big.NewInt(0), Data: data}
+	return u.Execute(sender, call, ExecuteOptions{SelfPaid: true})
+}
+
+// checkPrefund makes sure a self-paid operation can be paid for: the
+// EntryPoint takes the deposit first and the account tops it up from its
+// balance.
+func (u *Usecase) checkPrefund(userOp model.UserOperation) error {
+	deposit, err := u.GetWalletDeposit(userOp.Sender)
+	if err != nil {
+		return err
+	}
+
+	available := new(big.Int).Add(deposit.Deposit, deposit.Balance)
+	required := userOp.MaxGasCost()
+	if available.Cmp(required) < 0 {
+		return fmt.Errorf("%w: %s wei required, %s wei deposited and %s wei in balance",
+			ErrInsufficientPrefund, required, deposit.Deposit, deposit.Balance)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"fmt"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PreparedUserOperation is an operation ready for its owner's signature: the
+// owner personal-signs UserOpHash and hands the signature to
+// SubmitUserOperation along with the unchanged operation.
+type PreparedUserOperation struct {
+	UserOperation model.UserOperation `json:"userOperation"`
+	UserOpHash    common.Hash         `json:"userOpHash"`
+	Fees          model.Fees          `json:"fees"`
+	// set when the paymaster charges the wallet in its token
+	TokenQuote *TokenQuote `json:"tokenQuote,omitempty"`
+}
+
+// PrepareUserOperation builds the gas estimated, paymaster signed operation
+// for a client-side signed wallet.
+func (u *Usecase) PrepareUserOperation(simpleOp SimpleUserOperation) (PreparedUserOperation, error) {
+	sender, owner, err := u.resolveSender(simpleOp)
+	if err != nil {
+		return PreparedUserOperation{}, err
+	}
+
+	userOp, fees, err := u.buildUserOperation(simpleOp, sender, owner)
+	if err != nil {
+		return PreparedUserOperation{}, err
+	}
+
+	quote, err := u.quoteToken(userOp)
+	if err != nil {
+		return PreparedUserOperation{}, err
+	}
+
+	return PreparedUserOperation{
+		UserOperation: userOp,
+		UserOpHash:    userOp.Hash(u.contracts.EntryPointAddress, u.contracts.ChainId()),
+		Fees:          fees,
+		TokenQuote:    quote,
+	}, nil
+}
+
+// SubmitUserOperation checks that signature is the wallet owner's signature
+// of the operation's hash and forwards it to the bundler.
+func (u *Usecase) SubmitUserOperation(userOp model.UserOperation, signature []byte) (string, error) {
+	version, err := model.ParseEntryPointVersion(string(userOp.Version))
+	if err != nil {
+		return "", apperr.Invalid(err)
+	}
+	if version != u.contracts.EntryPointVersion {
+		return "", apperr.Invalidf("operation is for entry point %s, chain uses %s", version, u.contracts.EntryPointVersion)
+	}
+	userOp.Version = version
+	err = userOp.Validate()
+	if err != nil {
+		return "", apperr.Invalid(err)
+	}
+
+	owner, err := u.walletOwner(userOp.Sender)
+	if err != nil {
+		return "", err
+	}
+
+	userOpHash := userOp.Hash(u.contracts.EntryPointAddress, u.contracts.ChainId())
+	signer, err := recoverPersonalSigner(userOpHash[:], signature)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if signer != owner.address {
+		return "", fmt.Errorf("%w: recovered %s, owner is %s", ErrInvalidSignature, signer, owner.address)
+	}
+
+	userOp.Signature = signature
+	return u.submit(userOp, nil)
+}
+
+func recoverPersonalSigner(data []byte, signature []byte) (common.Address, error) {
+	if len(signature) != 65 {
+		return common.Address{}, fmt.Errorf("signature must be 65 bytes, got %d", len(signature))
+	}
+
+	sig := common.CopyBytes(signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	publicKey, err := crypto.SigToPub(accounts.TextHash(data), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"web3-account-abstraction-api/internal/apperr"
+	"web3-account-abstraction-api/internal/model"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInsufficientTokenBalance   = apperr.New(apperr.KindValidation, "insufficient_token_balance", "wallet token balance does not cover the quoted cost")
+	ErrInsufficientTokenAllowance = apperr.New(apperr.KindValidation, "insufficient_token_allowance", "wallet token allowance to the paymaster does not cover the quoted cost")
+)
+
+// TokenQuote is the most an operation can cost in the paymaster token, next
+// to what the wallet holds.
+type TokenQuote struct {
+	Token common.Address `json:"token"`
+	// wei: the operation's maximum gas cost plus the paymaster's postOp
+	MaxGasCost    *big.Int `json:"maxGasCost"`
+	TokenPerEther *big.Int `json:"tokenPerEther"`
+	TokenCost     *big.Int `json:"tokenCost"`
+	Balance       *big.Int `json:"balance"`
+	// nil when the paymaster is the token and charges without an allowance
+	Allowance *big.Int `json:"allowance,omitempty"`
+}
+
+// Check fails unless the wallet can pay the quoted cost.
+func (q TokenQuote) Check() error {
+	if q.Balance.Cmp(q.TokenCost) < 0 {
+		return fmt.Errorf("%w: %s required, %s held", ErrInsufficientTokenBalance, q.TokenCost, q.Balance)
+	}
+	if q.Allowance != nil && q.Allowance.Cmp(q.TokenCost) < 0 {
+		return fmt.Errorf("%w: %s required, %s approved", ErrInsufficientTokenAllowance, q.TokenCost, q.Allowance)
+	}
+	return nil
+}
+
+// QuoteUserOperation builds the operation as SendUserOperation would and
+// quotes its token cost; the quote is nil when the paymaster does not charge
+// in a token. It only reads: nothing is sent, minted or recorded.
+func (u *Usecase) QuoteUserOperation(simpleOp SimpleUserOperation) (*TokenQuote, error) {
+	sender, owner, err := u.resolveSender(simpleOp)
+	if err != nil {
+		return nil, err
+	}
+
+	userOp, _, err := u.buildUserOperation(simpleOp, sender, owner)
+	if err != nil {
+		return nil, err
+	}
+	return u.quoteToken(userOp)
+}
+
+// quoteToken returns nil for operations not paid in the paymaster token.
+func (u *Usecase) quoteToken(userOp model.UserOperation) (*TokenQuote, error) {
+	token := u.contracts.TokenAddress()
+	if token == nil || userOp.Paymaster == nil || *userOp.Paymaster != u.contracts.PaymasterAddress {
+		return nil, nil
+	}
+
+	price, err := u.price.Price(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	costOfPost, err := u.contracts.GetCostOfPost()
+	if err != nil {
+		return nil, err
+	}
+
+	maxGasCost := userOp.MaxGasCost()
+	if userOp.MaxFeePerGas != nil {
+		maxGasCost.Add(maxGasCost, new(big.Int).Mul(costOfPost, userOp.MaxFeePerGas))
+	}
+
+	balance, err := u.contracts.GetERC20Balance(userOp.Sender, *token)
+	if err != nil {
+		return nil, err
+	}
+	quote := &TokenQuote{
+		Token:         *token,
+		MaxGasCost:    maxGasCost,
+		TokenPerEther: price.TokenPerEther,
+		TokenCost:     price.ToToken(maxGasCost),
+		Balance:       balance,
+	}
+
+	if !u.contracts.PaymasterIsToken() {
+		quote.Allowance, err = u.contracts.GetERC20Allowance(userOp.Sender, u.contracts.PaymasterAddress, *token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return quote, nil
+}
+
+// mintInitialTokens gives a wallet being created initialETH worth of the
+// paymaster token, so its first operations can be paid for. A wallet already
+// holding the token is left as is, so it can be retried.
+func (u *Usecase) mintInitialTokens(sender common.Address) error {
+	balance, err := u.contracts.GetERC20Balance(sender, u.contracts.PaymasterAddress)
+	if err != nil {
+		return err
+	}
+	if balance.Sign() > 0 {
+		return nil
+	}
+
+	price, err := u.price.Price(context.Background())
+	if err != nil {
+		return err
+	}
+	return u.contracts.MintToken(sender, price.ToToken(u.initialETH))
+}
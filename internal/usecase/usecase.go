@@ -2,99 +2,170 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math"
 	"math/big"
-	"web3-account-abstraction-api/generated/abi/entrypoint"
+	"time"
+	"web3-account-abstraction-api/internal/apperr"
 	"web3-account-abstraction-api/internal/bundler"
 	contract "web3-account-abstraction-api/internal/contracts"
+	"web3-account-abstraction-api/internal/fee"
+	"web3-account-abstraction-api/internal/gas"
+	"web3-account-abstraction-api/internal/keys"
 	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/policy"
+	"web3-account-abstraction-api/internal/pricing"
+	"web3-account-abstraction-api/internal/store"
 	"web3-account-abstraction-api/utils"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/vipnode/ether"
 )
 
+var (
+	ErrUserOperationNotFound = apperr.New(apperr.KindNotFound, "user_operation_not_found", "user operation not found")
+	ErrClientSignedWallet    = apperr.New(apperr.KindValidation, "client_signed_wallet", "wallet is signed client-side, use prepare and submit")
+	ErrInvalidSignature      = apperr.New(apperr.KindValidation, "invalid_signature", "signature does not recover to the wallet owner")
+)
+
 type SimpleUserOperation struct {
+	// salt deploying Sender when it has no code yet, its recorded salt
+	// when nil
 	WalletSalt    []byte
 	CallData      []byte
 	Paymaster     *common.Address
 	PaymasterData []byte
-	Sender        *common.Address
+	// required, see resolveSender
+	Sender *common.Address
+	// speed the fees are chosen for, normal when empty
+	FeeTier model.FeeTier
 }
 
 type Usecase struct {
 	contracts contract.Contracts
 	bundler   bundler.Bundler
 	client    *ethclient.Client
+	store     store.Store
+	policy    *policy.Engine
+	price     pricing.Source
+	fees      *fee.Oracle
+	gas       *gas.Markups
+	estimator gas.Estimator
+	// nil keeps every new wallet owned by the configured PRIVATE_KEY
+	masterKey *keys.MasterKey
 
 	initialETH *big.Int
 }
 
-func NewUseCase(contracts contract.Contracts, bundler bundler.Bundler, client *ethclient.Client) Usecase {
+func NewUseCase(contracts contract.Contracts, bundler bundler.Bundler, client *ethclient.Client, store store.Store, policy *policy.Engine, price pricing.Source, fees *fee.Oracle, gas *gas.Markups, estimator gas.Estimator, masterKey *keys.MasterKey) Usecase {
 	initialETH, _ := ether.Parse("1 ether")
 	return Usecase{
 		contracts:  contracts,
 		bundler:    bundler,
 		client:     client,
+		store:      store,
+		policy:     policy,
+		price:      price,
+		fees:       fees,
+		gas:        gas,
+		estimator:  estimator,
+		masterKey:  masterKey,
 		initialETH: initialETH,
 	}
 }
 
-func markUpGas(gas *big.Int, percentage float64) *big.Int {
-	fl, _ := gas.Float64()
-	gasAfterMarkup := fl * percentage
-	return big.NewInt(int64(math.Floor(gasAfterMarkup)))
+// SendUserOperation builds, signs and submits an operation for a wallet whose
+// owner key the service holds.
+func (u *Usecase) SendUserOperation(simpleOp SimpleUserOperation) (string, error) {
+	sender, owner, err := u.resolveSender(simpleOp)
+	if err != nil {
+		return "", err
+	}
+	if owner.external {
+		return "", fmt.Errorf("%w: %s", ErrClientSignedWallet, sender)
+	}
+
+	userOp, fees, err := u.buildUserOperation(simpleOp, sender, owner)
+	if err != nil {
+		return "", err
+	}
+
+	userOpHash := userOp.Hash(u.contracts.EntryPointAddress, u.contracts.ChainId())
+	signature, err := u.contracts.Sign(owner.signer, userOpHash[:])
+	if err != nil {
+		return "", err
+	}
+	userOp.Signature = signature
+
+	return u.submit(userOp, &fees)
 }
 
-func (u *Usecase) SendUserOperation(simpleOp SimpleUserOperation) (string, error) {
-	var sender common.Address
-	var err error
-	if simpleOp.Sender != nil {
-		sender = *simpleOp.Sender
-	} else {
-		sender, err = u.contracts.GetSenderAddres([32]byte(simpleOp.WalletSalt))
-		if err != nil {
-			return "", err
-		}
+// resolveSender returns the operation's sender and who owns it. The sender
+// has to be given: its address could only be predicted from the salt with
+// the wallet's own owner, which is looked up by address.
+func (u *Usecase) resolveSender(simpleOp SimpleUserOperation) (common.Address, walletOwner, error) {
+	if simpleOp.Sender == nil {
+		return common.Address{}, walletOwner{}, apperr.Invalidf("the operation has no sender")
 	}
+	sender := *simpleOp.Sender
+
+	owner, err := u.walletOwner(sender)
+	if err != nil {
+		return common.Address{}, walletOwner{}, err
+	}
+	return sender, owner, nil
+}
 
+// buildUserOperation returns the gas estimated, paymaster signed but
+// unsigned operation and the fees chosen for it, attaching the deployment
+// when sender has no code yet. Quotes build through it too, so it must not
+// change any state.
+func (u *Usecase) buildUserOperation(simpleOp SimpleUserOperation, sender common.Address, owner walletOwner) (model.UserOperation, model.Fees, error) {
 	factory := common.HexToAddress("0x")
 	factoryData := []byte{}
 
 	contractCode, err := u.client.CodeAt(context.Background(), sender, nil)
 	if err != nil {
-		return "", err
+		return model.UserOperation{}, model.Fees{}, err
 	}
 
-	fmt.Printf("sender: %s\n", sender.Hex())
 	if len(contractCode) == 0 {
+		salt := simpleOp.WalletSalt
+		if salt == nil {
+			// counterfactual wallet: deploy it with its first operation
+			if owner.salt == nil {
+				return model.UserOperation{}, model.Fees{}, fmt.Errorf("wallet %s is not deployed and has no recorded salt", sender)
+			}
+			walletSalt := saltBytes(owner.salt)
+			salt = walletSalt[:]
+		}
+
 		factory = u.contracts.AccountFactoryAddress
 		factoryData, err = u.contracts.GetAccountFactoryCallData(
-			u.contracts.OwnerAddress(),
-			[32]byte(simpleOp.WalletSalt),
+			owner.address,
+			[32]byte(salt),
 			u.contracts.EntryPointAddress)
 		if err != nil {
-			return "", err
-		}
-		err = u.contracts.MintToken(sender, u.initialETH)
-		if err != nil {
-			return "", err
+			return model.UserOperation{}, model.Fees{}, err
 		}
 	}
 
-	nonce, err := u.contracts.EntryPoint.GetNonce(&bind.CallOpts{
-		Pending: false,
-	}, sender, big.NewInt(0))
+	nonce, err := u.contracts.GetNonce(sender)
 	if err != nil {
-		return "", err
+		return model.UserOperation{}, model.Fees{}, err
+	}
+	tier, err := model.ParseFeeTier(string(simpleOp.FeeTier))
+	if err != nil {
+		return model.UserOperation{}, model.Fees{}, apperr.Invalid(err)
+	}
+	fees, err := u.fees.Suggest(context.Background(), tier)
+	if err != nil {
+		return model.UserOperation{}, model.Fees{}, err
 	}
-	maxFeePerGas, _ := ether.Parse("10 gwei")
-	maxPriorityFeePerGas, _ := ether.Parse("5 gwei")
 
 	userOp := model.UserOperation{
+		Version:       u.contracts.EntryPointVersion,
 		Sender:        sender,
 		Nonce:         nonce,
 		Factory:       &factory,
@@ -104,69 +175,171 @@ func (u *Usecase) SendUserOperation(simpleOp SimpleUserOperation) (string, error
 		PaymasterData: simpleOp.PaymasterData,
 		Signature:     []byte{},
 
-		// Dummy value
+		// Dummy value, replaced by the estimate
 		CallGasLimit:                  big.NewInt(400_000),
 		VerificationGasLimit:          big.NewInt(400_000),
 		PaymasterVerificationGasLimit: big.NewInt(1_000_000),
 		PreVerificationGas:            big.NewInt(200_000),
-		MaxFeePerGas:                  maxFeePerGas,
-		MaxPriorityFeePerGas:          maxPriorityFeePerGas,
+		MaxFeePerGas:                  fees.MaxFeePerGas,
+		MaxPriorityFeePerGas:          fees.MaxPriorityFeePerGas,
 		PaymasterPostOpGasLimit:       big.NewInt(21_000),
 	}
+	sponsored := simpleOp.Paymaster != nil && !utils.IsZeroAddress(*simpleOp.Paymaster)
+	if sponsored {
+		// signed for real once the gas is known
+		err = userOp.EncodePaymasterData(0, 0, model.DummySignature)
+		if err != nil {
+			return model.UserOperation{}, model.Fees{}, err
+		}
+	} else {
+		userOp.PaymasterVerificationGasLimit = nil
+		userOp.PaymasterPostOpGasLimit = nil
+	}
 
-	estimateGasResult, err := u.bundler.EstimateUserOpGas(userOp)
+	estimateGasResult, err := u.estimator.EstimateUserOpGas(userOp)
 	if err != nil {
-		return "", err
+		return model.UserOperation{}, model.Fees{}, err
+	}
+	u.gas.Apply(&userOp, estimateGasResult, callTargets(sender, userOp.CallData))
+
+	if !sponsored {
+		err = u.checkPrefund(userOp)
+		if err != nil {
+			return model.UserOperation{}, model.Fees{}, err
+		}
+		return userOp, fees, nil
 	}
 
-	userOp.PreVerificationGas = markUpGas(estimateGasResult.PreVerificationGas, 1.1)
-	userOp.CallGasLimit = markUpGas(estimateGasResult.CallGasLimit, 1.1)
-	userOp.VerificationGasLimit = markUpGas(estimateGasResult.VerificationGasLimit, 1.1)
-	maxPriorityGasFee, err := u.bundler.GetMaxPriorityFeePerGas()
+	decision, err := u.policy.Evaluate(policy.Request{
+		Wallet:        sender,
+		UserOperation: userOp,
+		Calls:         policyCalls(sender, userOp.CallData),
+	})
 	if err != nil {
-		return "", err
+		return model.UserOperation{}, model.Fees{}, err
 	}
-	userOp.MaxPriorityFeePerGas = maxPriorityGasFee
 
-	baseFee, err := u.client.SuggestGasPrice(context.Background())
+	pmSignature, err := u.contracts.GetPaymasterSignature(userOp, decision.ValidAfter, decision.ValidUntil)
 	if err != nil {
-		return "", err
+		return model.UserOperation{}, model.Fees{}, err
 	}
-	userOp.MaxFeePerGas = baseFee.Add(baseFee, maxPriorityGasFee)
+	userOp.EncodePaymasterData(int(decision.ValidUntil.Unix()), int(decision.ValidAfter.Unix()), pmSignature)
 
-	if simpleOp.Paymaster != nil && !utils.IsZeroAddress(simpleOp.Paymaster) {
-		pmSignature, validAfter, validUntil, err := u.contracts.GetPaymasterSignature(userOp)
+	return userOp, fees, nil
+}
+
+// submit forwards a signed operation to the bundler and records it.
+// fees are nil when the client chose them. A sponsored operation is sent and
+// recorded under a spend reservation of the policy engine.
+func (u *Usecase) submit(userOp model.UserOperation, fees *model.Fees) (string, error) {
+	quote, err := u.quoteToken(userOp)
+	if err != nil {
+		return "", err
+	}
+	if quote != nil {
+		err = quote.Check()
 		if err != nil {
 			return "", err
 		}
-		userOp.EncodePaymasterData(int(validUntil), int(validAfter), pmSignature)
 	}
 
-	userOpHash, err := u.contracts.EntryPoint.GetUserOpHash(&bind.CallOpts{Pending: false}, entrypoint.PackedUserOperation(userOp.Pack()))
+	var hash string
+	send := func() error {
+		result, err := u.bundler.SendUserOperation(userOp)
+		if err != nil {
+			return err
+		}
+		hash = result.TxHash
+
+		now := time.Now()
+		return u.store.CreateUserOperation(model.UserOperationRecord{
+			Hash:          hash,
+			ChainID:       u.contracts.ChainId().Int64(),
+			Wallet:        userOp.Sender.Hex(),
+			UserOperation: userOp,
+			Status:        model.UserOperationStatusPending,
+			SubmittedAt:   now,
+			UpdatedAt:     now,
+			Fees:          fees,
+		})
+	}
+	if userOp.Paymaster != nil && !utils.IsZeroAddress(*userOp.Paymaster) {
+		err = u.policy.Reserve(policy.Request{
+			Wallet:        userOp.Sender,
+			UserOperation: userOp,
+			Calls:         policyCalls(userOp.Sender, userOp.CallData),
+		}, send)
+	} else {
+		err = send()
+	}
 	if err != nil {
 		return "", err
 	}
 
-	signature, err := u.contracts.Sign(userOpHash[:])
-	if err != nil {
-		return "", err
+	if userOp.Factory != nil && !utils.IsZeroAddress(*userOp.Factory) {
+		err = u.markWalletCreated(userOp.Sender)
+		if err != nil {
+			return "", err
+		}
+	}
+	return hash, nil
+}
+
+// callTargets lists the contracts userOp calls, see policyCalls.
+func callTargets(sender common.Address, callData []byte) []common.Address {
+	calls := policyCalls(sender, callData)
+	targets := make([]common.Address, 0, len(calls))
+	for _, call := range calls {
+		targets = append(targets, call.Target)
+	}
+	return targets
+}
+
+// policyCalls lists the calls a sponsorship policy has to check. A call made
+// through execute is checked as a call to its target; any other account call
+// (e.g. withdrawETH) is checked as a call to the wallet itself.
+func policyCalls(sender common.Address, callData []byte) []policy.Call {
+	if len(callData) == 0 {
+		return nil
 	}
-	userOp.Signature = signature
 
-	result, err := u.bundler.SendUserOperation(userOp)
+	call, err := DecodeCall(callData)
 	if err != nil {
-		return "", err
+		return []policy.Call{{Target: sender, Data: callData}}
 	}
+	return []policy.Call{{Target: call.Target, Data: call.Data}}
+}
 
-	return result.TxHash, nil
+// GetUserOperationStatus answers from the stored record, which the reconciler
+// keeps up to date with the bundler.
+func (u *Usecase) GetUserOperationStatus(hash string) (model.UserOperationRecord, error) {
+	record, err := u.store.GetUserOperation(hash)
+	if errors.Is(err, store.ErrNotFound) {
+		return model.UserOperationRecord{}, ErrUserOperationNotFound
+	}
+	return record, err
 }
 
-// only return success/failed
-func (u *Usecase) GetUserOperationStatus(hash string) (bool, error) {
+func (u *Usecase) GetUserOperationReceipt(hash string) (*bundler.UserOperationReceipt, error) {
 	receipt, err := u.bundler.GetUserOperationReceipt(hash)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, ErrUserOperationNotFound
+	}
+
+	return receipt, nil
+}
+
+func (u *Usecase) GetUserOperationByHash(hash string) (*bundler.UserOperationByHashResult, error) {
+	result, err := u.bundler.GetUserOperationByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ErrUserOperationNotFound
 	}
 
-	return receipt.Success, nil
+	return result, nil
 }
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"web3-account-abstraction-api/internal/model"
+	"web3-account-abstraction-api/internal/signer"
+	"web3-account-abstraction-api/internal/store"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func saltBytes(salt *big.Int) [32]byte {
+	var result [32]byte
+	salt.FillBytes(result[:])
+	return result
+}
+
+type CreateWalletOptions struct {
+	// register the predicted address only; the wallet is deployed by its
+	// first operation
+	Counterfactual bool
+	// client held owner key; such wallets sign their operations client-side
+	// and are always counterfactual
+	Owner *common.Address
+}
+
+// CreateWallet reserves the next salt of the chain and records the wallet.
+// Its owner is the client's when given; otherwise, with a master key
+// configured, the wallet gets its own owner key, stored encrypted, and
+// without one it is owned by the configured PRIVATE_KEY. With a token
+// paymaster, the wallet is minted its initial tokens once it is recorded;
+// DeployWallet retries a mint that failed. Unless counterfactual, it also deploys it through a sponsored, empty user
+// operation; when that fails the wallet stays reserved and DeployWallet
+// retries it with the same salt. Counterfactual wallets are only deployed by
+// their first operation.
+func (u *Usecase) CreateWallet(opts CreateWalletOptions) (model.UserWallet, error) {
+	counterfactual := opts.Counterfactual || opts.Owner != nil
+	chainId := u.contracts.ChainId().Int64()
+	salt, err := u.store.AllocateWalletSalt(chainId)
+	if err != nil {
+		return model.UserWallet{}, err
+	}
+
+	owner := u.contracts.OwnerAddress()
+	var ownerKey *model.EncryptedKey
+	if opts.Owner != nil {
+		owner = *opts.Owner
+	} else if u.masterKey != nil {
+		privateKey, sealed, err := u.masterKey.Generate()
+		if err != nil {
+			return model.UserWallet{}, err
+		}
+		owner = crypto.PubkeyToAddress(privateKey.PublicKey)
+		ownerKey = &sealed
+	}
+
+	addr, err := u.contracts.GetSenderAddres(owner, saltBytes(salt))
+	if err != nil {
+		return model.UserWallet{}, err
+	}
+
+	wallet := model.UserWallet{
+		ChainID:  chainId,
+		Sender:   addr.String(),
+		Salt:     salt,
+		Owner:    owner.String(),
+		Status:   model.WalletStatusReserved,
+		OwnerKey: ownerKey,
+	}
+	if counterfactual {
+		wallet.Status = model.WalletStatusUndeployed
+	}
+	err = u.store.CreateWallet(wallet)
+	if err != nil {
+		return model.UserWallet{}, err
+	}
+	// funded before the wallet's first operation is estimated against the
+	// token paymaster
+	if u.contracts.PaymasterIsToken() {
+		err = u.mintInitialTokens(addr)
+		if err != nil {
+			return wallet, fmt.Errorf("minting the initial tokens of wallet %s (retry the deployment): %w", wallet.Sender, err)
+		}
+	}
+	if counterfactual {
+		return wallet, nil
+	}
+
+	return u.DeployWallet(wallet)
+}
+
+// DeployWallet sends the deployment of a reserved or undeployed wallet. It is
+// a no-op for created wallets and only marks the wallet created when it
+// already has code, so retrying after a failure never deploys twice.
+func (u *Usecase) DeployWallet(wallet model.UserWallet) (model.UserWallet, error) {
+	if wallet.Status == model.WalletStatusCreated {
+		return wallet, nil
+	}
+	if wallet.Salt == nil {
+		return wallet, fmt.Errorf("wallet %s has no salt", wallet.Sender)
+	}
+
+	code, err := u.client.CodeAt(context.Background(), common.HexToAddress(wallet.Sender), nil)
+	if err != nil {
+		return wallet, err
+	}
+	if len(code) == 0 {
+		salt := saltBytes(wallet.Salt)
+		sender := common.HexToAddress(wallet.Sender)
+		// a no-op unless minting failed when the wallet was created
+		if u.contracts.PaymasterIsToken() {
+			err = u.mintInitialTokens(sender)
+			if err != nil {
+				return wallet, err
+			}
+		}
+		// TODO: adjust can use paymaster or not
+		simpleOp := SimpleUserOperation{
+			Sender:        &sender,
+			WalletSalt:    salt[:],
+			CallData:      common.FromHex("0x"),
+			Paymaster:     &u.contracts.PaymasterAddress,
+			PaymasterData: common.FromHex("0x"),
+		}
+
+		// marks the wallet created once the bundler accepts the operation
+		_, err = u.SendUserOperation(simpleOp)
+		if err != nil {
+			return wallet, fmt.Errorf("deploying wallet %s (%s, retry the deployment): %w", wallet.Sender, wallet.Status, err)
+		}
+		wallet.Status = model.WalletStatusCreated
+		return wallet, nil
+	}
+
+	wallet.Status = model.WalletStatusCreated
+	err = u.store.UpdateWallet(wallet)
+	if err != nil {
+		return wallet, err
+	}
+	return wallet, nil
+}
+
+type walletOwner struct {
+	address common.Address
+	// nil signs with the default owner
+	signer signer.Signer
+	// nil when sender is not a recorded wallet or predates stored salts
+	salt *big.Int
+	// the owner key is held by the client, see PrepareUserOperation
+	external bool
+}
+
+// walletOwner resolves who owns and signs for sender. Senders that are not
+// recorded wallets are owned by the configured PRIVATE_KEY.
+func (u *Usecase) walletOwner(sender common.Address) (walletOwner, error) {
+	owner := walletOwner{address: u.contracts.OwnerAddress()}
+
+	wallet, err := u.store.GetWallet(u.contracts.ChainId().Int64(), sender.String())
+	if errors.Is(err, store.ErrNotFound) {
+		return owner, nil
+	}
+	if err != nil {
+		return walletOwner{}, err
+	}
+
+	owner.salt = wallet.Salt
+	if wallet.Owner != "" {
+		owner.address = common.HexToAddress(wallet.Owner)
+	}
+	if wallet.OwnerKey == nil {
+		owner.external = owner.address != u.contracts.OwnerAddress()
+		return owner, nil
+	}
+
+	if u.masterKey == nil {
+		return walletOwner{}, fmt.Errorf("wallet %s has its own owner key but no master key is configured", sender)
+	}
+	privateKey, err := u.masterKey.Open(*wallet.OwnerKey)
+	if err != nil {
+		return walletOwner{}, fmt.Errorf("wallet %s: %w", sender, err)
+	}
+	owner.signer = signer.NewKeySigner(privateKey)
+	if owner.signer.Address() != owner.address {
+		return walletOwner{}, fmt.Errorf("wallet %s: owner key does not match owner %s", sender, owner.address)
+	}
+	return owner, nil
+}
+
+// markWalletCreated records that the deployment of sender was accepted by the
+// bundler. Senders that are not recorded wallets are ignored.
+func (u *Usecase) markWalletCreated(sender common.Address) error {
+	wallet, err := u.store.GetWallet(u.contracts.ChainId().Int64(), sender.String())
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if wallet.Status == model.WalletStatusCreated {
+		return nil
+	}
+
+	wallet.Status = model.WalletStatusCreated
+	return u.store.UpdateWallet(wallet)
+}
+
+// WalletAddress is the counterfactual address of the wallet of owner for
+// salt; a zero owner is the configured PRIVATE_KEY.
+func (u *Usecase) WalletAddress(owner common.Address, salt [32]byte) (common.Address, error) {
+	if owner == (common.Address{}) {
+		owner = u.contracts.OwnerAddress()
+	}
+	return u.contracts.GetSenderAddres(owner, salt)
+}
+
+// ExecuteOptions tunes how Execute pays for the operation.
+type ExecuteOptions struct {
+	// pay from the wallet's deposit instead of the paymaster
+	SelfPaid bool
+	FeeTier  model.FeeTier
+}
+
+// Execute sends a user operation from sender running call through
+// Account.execute.
+func (u *Usecase) Execute(sender common.Address, call Call, opts ExecuteOptions) (string, error) {
+	callData, err := u.contracts.GetExecuteCallData(call.Target, call.Value, call.Data)
+	if err != nil {
+		return "", err
+	}
+
+	simpleOp := SimpleUserOperation{
+		Sender:   &sender,
+		CallData: callData,
+		FeeTier:  opts.FeeTier,
+	}
+	if !opts.SelfPaid {
+		simpleOp.Paymaster = &u.contracts.PaymasterAddress
+		simpleOp.PaymasterData = common.FromHex("0x")
+	}
+	return u.SendUserOperation(simpleOp)
+}